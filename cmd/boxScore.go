@@ -0,0 +1,187 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func (ts *TeamStats) add(other TeamStats) {
+	others := other.fields()
+	for i, n := range ts.fields() {
+		*n += *others[i]
+	}
+}
+
+func (ts TeamStats) TotalBases() int {
+	return ts.Hits + ts.Doubles + 2*ts.Triples + 3*ts.HomeRuns
+}
+
+func (ts TeamStats) BattingAverage() float64 {
+	return ratio(ts.Hits, ts.AtBats)
+}
+
+// OnBasePct leaves out reaching on catcher's interference, as the official
+// figure does.
+func (ts TeamStats) OnBasePct() float64 {
+	return ratio(ts.Hits+ts.Walks+ts.HitByPitch, ts.AtBats+ts.Walks+ts.HitByPitch+ts.SacFlies)
+}
+
+func (ts TeamStats) SluggingPct() float64 {
+	return ratio(ts.TotalBases(), ts.AtBats)
+}
+
+// BABIP is the batting average on balls in play.
+func (ts TeamStats) BABIP() float64 {
+	return ratio(ts.Hits-ts.HomeRuns, ts.AtBats-ts.Strikeouts-ts.HomeRuns+ts.SacFlies)
+}
+
+// BoxTotals sums a team's box scores, and its opponents', over a run of
+// games. Games is the number of games with box scores; the rest are left out.
+type BoxTotals struct {
+	Games    int
+	Team     TeamStats
+	Opponent TeamStats
+}
+
+// DefensiveEfficiency is the share of the opponents' balls in play the team
+// turned into outs. The game logs don't record reaching on an error, so it is
+// approximated as one minus the opponents' BABIP.
+func (bt BoxTotals) DefensiveEfficiency() float64 {
+	if bt.Opponent.AtBats == 0 {
+		return 0
+	}
+	return 1 - bt.Opponent.BABIP()
+}
+
+func boxTotals(games []TeamGame) BoxTotals {
+	var bt BoxTotals
+	for _, game := range games {
+		if !game.Stats.Known() {
+			continue
+		}
+		bt.Games++
+		bt.Team.add(game.Stats)
+		bt.Opponent.add(game.OpponentStats)
+	}
+	return bt
+}
+
+// BoxTotals sums the season's box scores.
+func (s Season) BoxTotals() BoxTotals {
+	return boxTotals(s.Games)
+}
+
+// BoxTotalsBetween sums the box scores of the team's games from and to,
+// counted from 1 and inclusive.
+func (s Season) BoxTotalsBetween(from, to int) BoxTotals {
+	if from < 1 {
+		from = 1
+	}
+	if to > len(s.Games) {
+		to = len(s.Games)
+	}
+	if from > to {
+		return BoxTotals{}
+	}
+	return boxTotals(s.Games[from-1 : to])
+}
+
+type boxScoreRow struct {
+	Franchise string
+	Team      string
+	Year      int
+	Totals    BoxTotals
+}
+
+// boxScoreCmd represents the boxScore command
+var boxScoreCmd = &cobra.Command{
+	Use:   "boxScore",
+	Short: "Team batting, pitching and fielding totals from the game logs' box scores",
+	Long: `Sums the box score of every game a team played in a season, or in a window of its games,
+and reports batting average, on-base and slugging percentages for the team and its opponents,
+along with defensive efficiency. Games without a box score are left out.
+
+Inputs:
+
+year: The season.
+franchise: Only show this franchise, e.g. SFN. Every franchise is shown when it is left out.
+from, to: The first and last of the team's games to include, counted from 1. By default the
+whole season is included.
+format: "text" or "json".
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown format %q", format)
+		}
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+		franchise, err := cmd.Flags().GetString("franchise")
+		if err != nil {
+			return err
+		}
+		from, err := cmd.Flags().GetInt("from")
+		if err != nil {
+			return err
+		}
+		to, err := cmd.Flags().GetInt("to")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		var rows []boxScoreRow
+		for _, season := range teamsBySeason.SeasonsInYear(year) {
+			if franchise != "" && season.Franchise != franchise {
+				continue
+			}
+			last := to
+			if last == 0 {
+				last = len(season.Games)
+			}
+			rows = append(rows, boxScoreRow{
+				Franchise: season.Franchise,
+				Team:      season.Team,
+				Year:      season.Year,
+				Totals:    season.BoxTotalsBetween(from, last),
+			})
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("no games found for %d", year)
+		}
+		if format == "json" {
+			return writeJSON(rows)
+		}
+		fmt.Println("Team\tGames\tAVG\tOBP\tSLG\tHR\tSB\tE\tOppAVG\tOppOBP\tOppSLG\tDER")
+		for _, row := range rows {
+			team, opp := row.Totals.Team, row.Totals.Opponent
+			fmt.Printf("%s\t%d\t%s\t%s\t%s\t%d\t%d\t%d\t%s\t%s\t%s\t%s\n", row.Team, row.Totals.Games,
+				formatPct(team.BattingAverage()), formatPct(team.OnBasePct()), formatPct(team.SluggingPct()),
+				team.HomeRuns, team.StolenBases, team.Errors,
+				formatPct(opp.BattingAverage()), formatPct(opp.OnBasePct()), formatPct(opp.SluggingPct()),
+				formatPct(row.Totals.DefensiveEfficiency()))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(boxScoreCmd)
+	boxScoreCmd.Flags().Int("year", 0, "season to report on")
+	boxScoreCmd.Flags().String("franchise", "", "only show this franchise")
+	boxScoreCmd.Flags().Int("from", 1, "first of the team's games to include")
+	boxScoreCmd.Flags().Int("to", 0, "last of the team's games to include; 0 for the end of the season")
+	boxScoreCmd.Flags().String("format", "text", "output format: text or json")
+	boxScoreCmd.MarkFlagRequired("year")
+}
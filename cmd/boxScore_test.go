@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamStatsRates(t *testing.T) {
+	ts := TeamStats{AtBats: 500, Hits: 130, Doubles: 25, Triples: 3, HomeRuns: 17, Walks: 45, HitByPitch: 5, SacFlies: 4, Strikeouts: 110}
+	assert.Equal(t, 212, ts.TotalBases())
+	assert.InDelta(t, .260, ts.BattingAverage(), 1e-9)
+	assert.InDelta(t, 180.0/554, ts.OnBasePct(), 1e-9)
+	assert.InDelta(t, .424, ts.SluggingPct(), 1e-9)
+	assert.InDelta(t, 113.0/377, ts.BABIP(), 1e-9)
+
+	bt := BoxTotals{Games: 1, Opponent: ts}
+	assert.InDelta(t, 1-113.0/377, bt.DefensiveEfficiency(), 1e-9)
+	assert.Zero(t, BoxTotals{}.DefensiveEfficiency())
+}
+
+func TestSeasonBoxTotals(t *testing.T) {
+	teamsBySeason, err := GetTeamsBySeason("./test_data")
+	require.NoError(t, err)
+	season := teamsBySeason.m["SFN"][2000]
+
+	bt := season.BoxTotals()
+	assert.Equal(t, 2, bt.Games)
+	assert.Equal(t, 54, bt.Team.AtBats)
+	assert.Equal(t, 14, bt.Team.Hits)
+	assert.Equal(t, 1, bt.Team.HomeRuns)
+	assert.Equal(t, season.Games[0].OpponentStats.AtBats+season.Games[1].OpponentStats.AtBats, bt.Opponent.AtBats)
+
+	first := season.BoxTotalsBetween(1, 1)
+	assert.Equal(t, BoxTotals{Games: 1, Team: season.Games[0].Stats, Opponent: season.Games[0].OpponentStats}, first)
+	assert.Equal(t, bt, season.BoxTotalsBetween(0, 10))
+	assert.Equal(t, BoxTotals{}, season.BoxTotalsBetween(2, 1))
+
+	// Games without a box score are left out.
+	season.Games[1].Stats = TeamStats{}
+	assert.Equal(t, first, season.BoxTotals())
+}
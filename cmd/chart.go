@@ -0,0 +1,157 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// chartColors are the line colors of successive series.
+var chartColors = []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b", "#e377c2", "#7f7f7f", "#bcbd22", "#17becf"}
+
+const (
+	chartWidth  = 800
+	chartHeight = 400
+	chartMargin = 50
+)
+
+// ChartPoint is one point of a series.
+type ChartPoint struct {
+	X, Y float64
+}
+
+// ChartSeries is one labeled line of a chart.
+type ChartSeries struct {
+	Label  string
+	Points []ChartPoint
+}
+
+// LineChart is a chart of one or more series drawn as SVG. The y axis always
+// takes in 0, which is drawn darker than the other grid lines.
+type LineChart struct {
+	XLabel string
+	YLabel string
+	// SignedY puts a plus sign on positive y ticks.
+	SignedY bool
+	// Whole keeps ticks on whole numbers, for charts of counts.
+	Whole  bool
+	Series []ChartSeries
+}
+
+// chartStep picks a round tick spacing that puts at most about ten ticks
+// across span.
+func chartStep(span float64) float64 {
+	if span <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(span/10)))
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if span/(m*magnitude) <= 10 {
+			return m * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// formatTick labels a tick with as many decimals as its step needs.
+func formatTick(v, step float64, signed bool) string {
+	decimals := 0
+	for scaled := step; decimals < 6 && math.Abs(scaled-math.Round(scaled)) > 1e-9; scaled *= 10 {
+		decimals++
+	}
+	label := strconv.FormatFloat(v, 'f', decimals, 64)
+	if signed && v > 0 {
+		label = "+" + label
+	}
+	return label
+}
+
+// WriteSVG draws the chart as a standalone SVG document.
+func (c LineChart) WriteSVG(w io.Writer) error {
+	_, err := io.WriteString(w, c.SVG())
+	return err
+}
+
+// SVG draws the chart, with a legend in the top left corner.
+func (c LineChart) SVG() string {
+	xMin, xMax, yMin, yMax := math.Inf(1), math.Inf(-1), 0.0, 0.0
+	for _, series := range c.Series {
+		for _, point := range series.Points {
+			xMin = math.Min(xMin, point.X)
+			xMax = math.Max(xMax, point.X)
+			yMin = math.Min(yMin, point.Y)
+			yMax = math.Max(yMax, point.Y)
+		}
+	}
+	if math.IsInf(xMin, 0) {
+		xMin, xMax = 0, 1
+	}
+	if xMax == xMin {
+		xMax = xMin + 1
+	}
+	step := func(span float64) float64 {
+		s := chartStep(span)
+		if c.Whole && s < 1 {
+			return 1
+		}
+		return s
+	}
+	yStep := step(yMax - yMin)
+	// Pad a step beyond the data, but not past 0.
+	if yMin < 0 {
+		yMin = (math.Floor(yMin/yStep) - 1) * yStep
+	}
+	if yMax > 0 {
+		yMax = (math.Ceil(yMax/yStep) + 1) * yStep
+	}
+	if yMax == yMin {
+		yMax = yMin + yStep
+	}
+
+	plotWidth := float64(chartWidth - 2*chartMargin)
+	plotHeight := float64(chartHeight - 2*chartMargin)
+	x := func(v float64) float64 { return chartMargin + (v-xMin)/(xMax-xMin)*plotWidth }
+	y := func(v float64) float64 { return chartMargin + (yMax-v)/(yMax-yMin)*plotHeight }
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		chartWidth, chartHeight, chartWidth, chartHeight)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="white"/>`+"\n", chartWidth, chartHeight)
+
+	for i := 0; yMin+float64(i)*yStep <= yMax+yStep/2; i++ {
+		v := yMin + float64(i)*yStep
+		stroke := "#e0e0e0"
+		if math.Abs(v) < yStep/2 {
+			v, stroke = 0, "#404040"
+		}
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`+"\n", x(xMin), y(v), x(xMax), y(v), stroke)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="end" dominant-baseline="middle">%s</text>`+"\n", x(xMin)-6, y(v), formatTick(v, yStep, c.SignedY))
+	}
+	xStep := step(xMax - xMin)
+	for v := math.Ceil(xMin/xStep) * xStep; v <= xMax+xStep/1e6; v += xStep {
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`+"\n", x(v), y(yMin)+18, formatTick(v, xStep, false))
+	}
+	fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n", x((xMin+xMax)/2), chartHeight-6, html.EscapeString(c.XLabel))
+	mid := y((yMin + yMax) / 2)
+	fmt.Fprintf(&b, `<text x="14" y="%.1f" text-anchor="middle" transform="rotate(-90 14 %.1f)">%s</text>`+"\n", mid, mid, html.EscapeString(c.YLabel))
+
+	for i, series := range c.Series {
+		color := chartColors[i%len(chartColors)]
+		points := make([]string, len(series.Points))
+		for j, point := range series.Points {
+			points[j] = fmt.Sprintf("%.1f,%.1f", x(point.X), y(point.Y))
+		}
+		fmt.Fprintf(&b, `<polyline fill="none" stroke="%s" stroke-width="2" points="%s"/>`+"\n", color, strings.Join(points, " "))
+		legendY := chartMargin + 16*i
+		fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="2"/>`+"\n", chartMargin+10, legendY, chartMargin+30, legendY, color)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" dominant-baseline="middle">%s</text>`+"\n", chartMargin+36, legendY, html.EscapeString(series.Label))
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
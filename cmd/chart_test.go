@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChartStep(t *testing.T) {
+	tests := []struct {
+		span float64
+		want float64
+	}{
+		{span: 0, want: 1},
+		{span: 7, want: 1},
+		{span: 30, want: 5},
+		{span: 162, want: 20},
+		{span: 0.3, want: 0.05},
+	}
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, chartStep(tt.span), 1e-12, "span %v", tt.span)
+	}
+}
+
+func TestFormatTick(t *testing.T) {
+	assert.Equal(t, "+3", formatTick(3, 1, true))
+	assert.Equal(t, "-2", formatTick(-2, 1, true))
+	assert.Equal(t, "0", formatTick(0, 1, true))
+	assert.Equal(t, "0.30", formatTick(0.1+0.2, 0.05, false))
+	assert.Equal(t, "7.5", formatTick(7.5, 2.5, false))
+}
+
+func TestLineChartSVG(t *testing.T) {
+	chart := LineChart{
+		XLabel: "Season",
+		YLabel: "% of games",
+		Series: []ChartSeries{{Label: "A & B", Points: []ChartPoint{{X: 2000, Y: 0.2}, {X: 2001, Y: 0.4}}}},
+	}
+	svg := chart.SVG()
+	assert.True(t, strings.HasPrefix(svg, "<svg "))
+	assert.Contains(t, svg, ">A &amp; B<")
+	assert.Contains(t, svg, ">% of games<")
+	assert.Contains(t, svg, `stroke="#404040"`, "the zero line is drawn")
+	assert.Equal(t, 1, strings.Count(svg, "<polyline"))
+	assert.NotContains(t, svg, "0000000")
+}
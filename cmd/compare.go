@@ -7,6 +7,8 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -48,6 +50,27 @@ func (gc *gameCombos) Add(key string, val seasonDetails) {
 	}
 }
 
+// Sort orders the details of every combo by season, team and starting game, so
+// results don't depend on the order in which records were hashed.
+func (gc *gameCombos) Sort() {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	for _, details := range gc.combos {
+		sort.Slice(details, func(i, j int) bool {
+			if details[i].season != details[j].season {
+				return details[i].season < details[j].season
+			}
+			if details[i].team != details[j].team {
+				return details[i].team < details[j].team
+			}
+			if details[i].length != details[j].length {
+				return details[i].length < details[j].length
+			}
+			return details[i].gameStart < details[j].gameStart
+		})
+	}
+}
+
 // compareCmd represents the compare command
 var compareCmd = &cobra.Command{
 	Use:   "compare",
@@ -59,6 +82,14 @@ Inputs:
 in-file: The path the CSV containing the data
 min-game-window: The lower bound of game-streak to look for.
 max-game-window: The upper bound of game-streak to look for.
+simulations: If set, simulate the dataset this many times from each season's win rate and
+label every match with a p-value: how often a match at least that long shows up by chance.
+seed: Seed for the simulations.
+home-away: Simulate home and road games with separate win rates, reading each season's
+home/away sequence from the Retrosheet game logs.
+similarity: After each match, contrast the matched windows' runs scored and allowed, one-run
+games and home/away mix from the Retrosheet game logs, with an overall similarity score from 0
+to 1. The in-file must come from the transform command so its team codes match the game logs.
 
 For example, if you the min is 30 and the max is 35, the script will find all instances where two seasons matched exactly for 30, 31, 32, 33, 34, and 35 games.
 `,
@@ -80,6 +111,22 @@ For example, if you the min is 30 and the max is 35, the script will find all in
 		if err != nil {
 			return err
 		}
+		simulations, err := cmd.Flags().GetInt("simulations")
+		if err != nil {
+			return err
+		}
+		seed, err := cmd.Flags().GetInt64("seed")
+		if err != nil {
+			return err
+		}
+		homeAway, err := cmd.Flags().GetBool("home-away")
+		if err != nil {
+			return err
+		}
+		similarity, err := cmd.Flags().GetBool("similarity")
+		if err != nil {
+			return err
+		}
 
 		f, err := os.Open(inFilePath)
 		if err != nil {
@@ -99,18 +146,108 @@ For example, if you the min is 30 and the max is 35, the script will find all in
 			return err
 		}
 
+		var longest []int
+		if simulations > 0 {
+			var schedule homeSchedule
+			if homeAway {
+				schedule, err = getHomeSchedule(rsDataDir)
+				if err != nil {
+					return err
+				}
+			}
+			models := seasonModelsFromRecords(records, schedule)
+			longest, err = simulateLongestMatches(models, minGameWindow, maxGameWindow, winningConstraint, simulations, seed)
+			if err != nil {
+				return err
+			}
+		}
+
+		htmlPath, err := cmd.Flags().GetString("html")
+		if err != nil {
+			return err
+		}
+		if htmlPath != "" {
+			report := Report{
+				Title:    fmt.Sprintf("Matching %d-%d game stretches", minGameWindow, maxGameWindow),
+				Sections: []ReportSection{matchReportSection(combos, longest)},
+			}
+			if err := writeReportFile(htmlPath, report); err != nil {
+				return err
+			}
+		}
+
+		var seasons map[seasonKey]*Season
+		if similarity {
+			teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+			if err != nil {
+				return err
+			}
+			seasons = seasonsByTeam(teamsBySeason)
+		}
+
 		for match := range combos.matches {
 			details := combos.combos[match]
-			fmt.Println("Match Found: ", match)
+			if simulations > 0 {
+				fmt.Printf("Match Found:  %s p-value: %.4f\n", match, matchPValue(longest, len(match)))
+			} else {
+				fmt.Println("Match Found: ", match)
+			}
 			for _, detail := range details {
 				fmt.Printf("%+v\n", detail)
 			}
+			if similarity {
+				for _, ws := range matchSimilarities(details, seasons) {
+					printSimilarity(ws)
+				}
+			}
 		}
 
 		return nil
 	},
 }
 
+// sortedMatches returns the matched sequences longest first.
+func sortedMatches(combos *gameCombos) []string {
+	var matches []string
+	for match := range combos.matches {
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if len(matches[i]) != len(matches[j]) {
+			return len(matches[i]) > len(matches[j])
+		}
+		return matches[i] < matches[j]
+	})
+	return matches
+}
+
+// matchReportSection tabulates every match, longest first, with its p-value
+// when longest holds simulation results.
+func matchReportSection(combos *gameCombos, longest []int) ReportSection {
+	table := &ReportTable{Columns: []string{"Games", "Record", "Results", "Seasons"}}
+	if longest != nil {
+		table.Columns = append(table.Columns, "P-value")
+	}
+	for _, match := range sortedMatches(combos) {
+		var windows []string
+		for _, detail := range combos.combos[match] {
+			windows = append(windows, fmt.Sprintf("%s %s games %d-%d", detail.season, detail.team, detail.gameStart, detail.gameEnd))
+		}
+		wins := strings.Count(match, "W")
+		row := []string{
+			strconv.Itoa(len(match)),
+			fmt.Sprintf("%d-%d", wins, strings.Count(match, "L")),
+			match,
+			strings.Join(windows, "; "),
+		}
+		if longest != nil {
+			row = append(row, fmt.Sprintf("%.4f", matchPValue(longest, len(match))))
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return ReportSection{Heading: "Matches", Table: table}
+}
+
 func findMatches(records [][]string, combos *gameCombos, minGameWindow, maxGameWindow, winningConstraint int) error {
 	var eg errgroup.Group
 
@@ -127,7 +264,11 @@ func findMatches(records [][]string, combos *gameCombos, minGameWindow, maxGameW
 		})
 	}
 
-	return eg.Wait()
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	combos.Sort()
+	return nil
 }
 
 func calculateHashes(record []string, combos *gameCombos, minGameWindow, maxGameWindow, winningConstraint int) {
@@ -179,6 +320,11 @@ func init() {
 	compareCmd.Flags().Int("min-game-window", 0, "lower bound of game window to compare")
 	compareCmd.Flags().Int("max-game-window", 0, "upper bound of game window to compare")
 	compareCmd.Flags().Int("winning-constraint", 0, "streak must have at least this pct of wins")
+	compareCmd.Flags().Int("simulations", 0, "number of Monte Carlo simulations used to compute p-values")
+	compareCmd.Flags().Int64("seed", 1, "seed for the simulations")
+	compareCmd.Flags().Bool("home-away", false, "simulate home and road games separately using the Retrosheet game logs")
+	compareCmd.Flags().Bool("similarity", false, "report how alike each match's windows were in runs, one-run games and home/away mix")
+	compareCmd.Flags().String("html", "", "also write the matches to this HTML report file")
 	compareCmd.MarkFlagRequired("in-file")
 	compareCmd.MarkFlagRequired("min-game-window")
 	compareCmd.MarkFlagRequired("max-game-window")
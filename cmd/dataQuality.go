@@ -0,0 +1,83 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// lineScoreProblems returns every game whose line scores don't parse or don't
+// add up to the final score, in date order, along with the number of games
+// with an unknown or partly unknown line score.
+func lineScoreProblems(dir string) ([]error, int, error) {
+	type problem struct {
+		game *RetrosheetGame
+		err  error
+	}
+	var (
+		mu       sync.Mutex
+		problems []problem
+		unknown  int
+	)
+	err := ByRetrosheetGame(dir, func(game *RetrosheetGame) error {
+		visiting, home, err := game.LineScores()
+		var (
+			syntax   *LineScoreSyntaxError
+			mismatch *LineScoreMismatchError
+		)
+		if err != nil && !errors.As(err, &syntax) && !errors.As(err, &mismatch) {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			if syntax != nil {
+				err = fmt.Errorf("%s %s @ %s: %w", game.Date.Format("2006-01-02"), game.VisitingTeam, game.HomeTeam, err)
+			}
+			problems = append(problems, problem{game: game, err: err})
+		} else if !visiting.Complete() || !home.Complete() {
+			unknown++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	sort.Slice(problems, func(i, j int) bool { return retrosheetGameLess(problems[i].game, problems[j].game) })
+	errs := make([]error, len(problems))
+	for i, p := range problems {
+		errs[i] = p.err
+	}
+	return errs, unknown, nil
+}
+
+// dataQualityCmd represents the dataQuality command
+var dataQualityCmd = &cobra.Command{
+	Use:   "dataQuality",
+	Short: "List games whose line score disagrees with the final score",
+	Long: `Checks every game's line scores, listing those that can't be parsed or whose runs don't add
+up to the final score. Games without a line score, or with innings left blank, are counted but
+not listed. Where innings are blank, the known innings only have to not add up to more than
+the final score.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problems, unknown, err := lineScoreProblems(rsDataDir)
+		if err != nil {
+			return err
+		}
+		for _, problem := range problems {
+			fmt.Println(problem)
+		}
+		fmt.Printf("%d games with line score problems, %d games without a complete line score\n", len(problems), unknown)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dataQualityCmd)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// DoubleheaderStats summarizes the doubleheaders of one period. A doubleheader
+// with a tie in either game is counted but is neither a sweep nor a split.
+type DoubleheaderStats struct {
+	Period        string
+	Games         int
+	Doubleheaders int
+	Sweeps        int
+	HomeSweeps    int
+	Splits        int
+	// HomeWins and DecidedGames cover every game of the period, for the sweep
+	// rate expected if the two games were independent.
+	HomeWins     int
+	DecidedGames int
+}
+
+func (ds DoubleheaderStats) SweepPct() float64 {
+	return pct(ds.Sweeps, ds.Sweeps+ds.Splits)
+}
+
+func (ds DoubleheaderStats) SplitPct() float64 {
+	return pct(ds.Splits, ds.Sweeps+ds.Splits)
+}
+
+// ExpectedSweepPct is the chance one team wins both games when each game is
+// won by the home team at the period's home winning percentage. It ignores
+// the difference in the teams' strength, so real sweeps run a little higher.
+func (ds DoubleheaderStats) ExpectedSweepPct() float64 {
+	if ds.DecidedGames == 0 {
+		return 0
+	}
+	p := float64(ds.HomeWins) / float64(ds.DecidedGames)
+	return (p*p + (1-p)*(1-p)) * 100
+}
+
+type doubleheader struct {
+	first, second *RetrosheetGame
+}
+
+// findDoubleheaders pairs the first two games played by the same teams at the
+// same park on the same day. The third game of a tripleheader is left out.
+func findDoubleheaders(games []*RetrosheetGame) []doubleheader {
+	firsts := make(map[string]*RetrosheetGame)
+	for _, game := range games {
+		if game.GameNumber == 1 {
+			firsts[doubleheaderKey(game)] = game
+		}
+	}
+	var doubleheaders []doubleheader
+	for _, game := range games {
+		if game.GameNumber != 2 {
+			continue
+		}
+		if first, ok := firsts[doubleheaderKey(game)]; ok {
+			doubleheaders = append(doubleheaders, doubleheader{first: first, second: game})
+		}
+	}
+	return doubleheaders
+}
+
+func doubleheaderKey(game *RetrosheetGame) string {
+	return game.Date.Format("20060102") + game.HomeTeam + game.VisitingTeam
+}
+
+// doubleheaderStats groups games by period and tallies sweeps and splits.
+// Games must be in chronological order.
+func doubleheaderStats(games []*RetrosheetGame, by string) ([]DoubleheaderStats, error) {
+	var rows []DoubleheaderStats
+	index := make(map[string]int)
+	row := func(year int) (*DoubleheaderStats, error) {
+		period, err := periodLabel(by, year)
+		if err != nil {
+			return nil, err
+		}
+		i, ok := index[period]
+		if !ok {
+			i = len(rows)
+			index[period] = i
+			rows = append(rows, DoubleheaderStats{Period: period})
+		}
+		return &rows[i], nil
+	}
+
+	for _, game := range games {
+		r, err := row(game.Date.Year())
+		if err != nil {
+			return nil, err
+		}
+		r.Games++
+		switch game.HomeResult(resultMode) {
+		case Win:
+			r.HomeWins++
+			r.DecidedGames++
+		case Loss:
+			r.DecidedGames++
+		}
+	}
+
+	for _, dh := range findDoubleheaders(games) {
+		r, err := row(dh.first.Date.Year())
+		if err != nil {
+			return nil, err
+		}
+		r.Doubleheaders++
+		first, second := dh.first.HomeResult(resultMode), dh.second.HomeResult(resultMode)
+		if first == Tie || second == Tie {
+			continue
+		}
+		if first == second {
+			r.Sweeps++
+			if first == Win {
+				r.HomeSweeps++
+			}
+		} else {
+			r.Splits++
+		}
+	}
+	return rows, nil
+}
+
+// doubleheadersCmd represents the doubleheaders command
+var doubleheadersCmd = &cobra.Command{
+	Use:   "doubleheaders",
+	Short: "How often doubleheaders are swept or split",
+	Long: `Pairs the games of each doubleheader using Retrosheet's game number and counts sweeps
+and splits by period, alongside the sweep rate expected if the two games were independent.
+
+Inputs:
+
+by: Group games by "era", "decade" or "year".
+format: "text" or "json".
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		by, err := cmd.Flags().GetString("by")
+		if err != nil {
+			return err
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown format %q", format)
+		}
+		games, err := sortedRetrosheetGames(rsDataDir)
+		if err != nil {
+			return err
+		}
+		rows, err := doubleheaderStats(games, by)
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			return writeJSON(rows)
+		}
+		fmt.Println("Period\tGames\tDoubleheaders\tOfGames\tSweeps\tHomeSweeps\tSplits\tSweepPct\tExpectedSweepPct")
+		for _, row := range rows {
+			fmt.Printf("%s\t%d\t%d\t%.1f%%\t%d\t%d\t%d\t%.1f%%\t%.1f%%\n", row.Period, row.Games, row.Doubleheaders, pct(row.Doubleheaders*2, row.Games), row.Sweeps, row.HomeSweeps, row.Splits, row.SweepPct(), row.ExpectedSweepPct())
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doubleheadersCmd)
+	doubleheadersCmd.Flags().String("by", "era", "group games by era, decade or year")
+	doubleheadersCmd.Flags().String("format", "text", "output format: text or json")
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoubleheaderStats(t *testing.T) {
+	game := func(day, gameNumber int, home, visitor string, homeScore, visitingScore int) *RetrosheetGame {
+		return &RetrosheetGame{
+			Date:          time.Date(1950, time.July, day, 0, 0, 0, 0, time.UTC),
+			GameNumber:    gameNumber,
+			HomeTeam:      home,
+			VisitingTeam:  visitor,
+			HomeScore:     homeScore,
+			VisitingScore: visitingScore,
+		}
+	}
+	games := []*RetrosheetGame{
+		// Home sweep.
+		game(1, 1, "BOS", "NYA", 5, 2),
+		game(1, 2, "BOS", "NYA", 3, 1),
+		// Split.
+		game(2, 1, "BOS", "NYA", 1, 2),
+		game(2, 2, "BOS", "NYA", 4, 1),
+		// Same day, different park: not a doubleheader with the games above.
+		game(2, 1, "CHA", "DET", 1, 0),
+		// Visitors sweep.
+		game(3, 1, "CHA", "DET", 0, 3),
+		game(3, 2, "CHA", "DET", 2, 6),
+		// A tie in the nightcap is neither.
+		game(4, 1, "CHA", "DET", 3, 2),
+		game(4, 2, "CHA", "DET", 1, 1),
+		game(5, 0, "CHA", "DET", 1, 0),
+	}
+	assert.Len(t, findDoubleheaders(games), 4)
+
+	rows, err := doubleheaderStats(games, "decade")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	row := rows[0]
+	assert.Equal(t, "1950s", row.Period)
+	assert.Equal(t, 10, row.Games)
+	assert.Equal(t, 4, row.Doubleheaders)
+	assert.Equal(t, 2, row.Sweeps)
+	assert.Equal(t, 1, row.HomeSweeps)
+	assert.Equal(t, 1, row.Splits)
+	assert.Equal(t, 6, row.HomeWins)
+	assert.Equal(t, 9, row.DecidedGames)
+	assert.InDelta(t, 55.6, row.ExpectedSweepPct(), 0.1)
+}
+
+func TestTeamGameLess(t *testing.T) {
+	day := time.Date(1950, time.July, 4, 0, 0, 0, 0, time.UTC)
+	first := TeamGame{Date: day, GameNumber: 1, TeamGameNumber: 80}
+	second := TeamGame{Date: day, GameNumber: 2, TeamGameNumber: 81}
+	assert.True(t, teamGameLess(first, second))
+	assert.False(t, teamGameLess(second, first))
+
+	// Without team game numbers the doubleheader's game number decides.
+	first.TeamGameNumber, second.TeamGameNumber = 0, 0
+	assert.True(t, teamGameLess(first, second))
+}
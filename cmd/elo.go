@@ -0,0 +1,370 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type EloConfig struct {
+	InitialRating float64
+	K             float64
+
+	// HomeAdvantage is added to the home team's rating when computing the
+	// pre-game win probability.
+	HomeAdvantage float64
+
+	// MarginOfVictory scales each update by the run differential, damped for
+	// heavy favorites so ratings don't run away.
+	MarginOfVictory bool
+
+	// Regression is the fraction of the distance to InitialRating each
+	// franchise gives back between seasons.
+	Regression float64
+}
+
+type EloPoint struct {
+	Date   time.Time
+	Rating float64
+}
+
+type EloGame struct {
+	Date              time.Time
+	HomeFranchise     string
+	VisitingFranchise string
+	HomeScore         int
+	VisitingScore     int
+	// HomeResult is the result the ratings were updated with, which for a
+	// forfeit can differ from the score.
+	HomeResult             Result
+	HomeRating             float64 // before the game
+	VisitingRating         float64 // before the game
+	HomeWinProbability     float64
+	VisitingWinProbability float64
+}
+
+// WinnerProbability is the pre-game probability the eventual winner was
+// given, or -1 for ties.
+func (eg EloGame) WinnerProbability() float64 {
+	switch eg.HomeResult {
+	case Win:
+		return eg.HomeWinProbability
+	case Loss:
+		return eg.VisitingWinProbability
+	}
+	return -1
+}
+
+type eloEngine struct {
+	config             EloConfig
+	franchiseConverter FranchiseConverter
+	ratings            map[string]float64
+	lastYear           map[string]int
+	history            map[string][]EloPoint
+	games              []EloGame
+}
+
+func newEloEngine(config EloConfig, franchiseConverter FranchiseConverter) *eloEngine {
+	return &eloEngine{
+		config:             config,
+		franchiseConverter: franchiseConverter,
+		ratings:            make(map[string]float64),
+		lastYear:           make(map[string]int),
+		history:            make(map[string][]EloPoint),
+	}
+}
+
+// rating returns the franchise's rating going into a game in year, applying
+// the between-season regression the first time the franchise plays that year.
+func (ee *eloEngine) rating(franchise string, year int) float64 {
+	rating, ok := ee.ratings[franchise]
+	if !ok {
+		rating = ee.config.InitialRating
+	} else if ee.lastYear[franchise] != year {
+		rating = ee.config.InitialRating + (rating-ee.config.InitialRating)*(1-ee.config.Regression)
+	}
+	ee.lastYear[franchise] = year
+	return rating
+}
+
+func eloExpected(rating, opponentRating float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponentRating-rating)/400))
+}
+
+// movMultiplier scales the K-factor by the margin of victory. The
+// winnerEloDiff term shrinks the update when the favorite wins big, which
+// would otherwise inflate ratings of teams that are already strong.
+func movMultiplier(margin int, winnerEloDiff float64) float64 {
+	if margin < 0 {
+		margin = -margin
+	}
+	return math.Log(float64(margin)+1) * 2.2 / (winnerEloDiff*0.001 + 2.2)
+}
+
+// AddGame updates both franchises' ratings. Games must be added in
+// chronological order.
+func (ee *eloEngine) AddGame(game *RetrosheetGame) {
+	year := game.Date.Year()
+	home := ee.franchiseConverter.Convert(game.HomeTeam)
+	visitor := ee.franchiseConverter.Convert(game.VisitingTeam)
+	homeRating := ee.rating(home, year)
+	visitingRating := ee.rating(visitor, year)
+
+	homeExpected := eloExpected(homeRating+ee.config.HomeAdvantage, visitingRating)
+	homeResult := game.HomeResult(resultMode)
+	var homeActual float64
+	switch homeResult {
+	case Win:
+		homeActual = 1
+	case Tie:
+		homeActual = 0.5
+	}
+
+	k := ee.config.K
+	if ee.config.MarginOfVictory && homeActual != 0.5 {
+		winnerEloDiff := homeRating + ee.config.HomeAdvantage - visitingRating
+		if homeActual == 0 {
+			winnerEloDiff = -winnerEloDiff
+		}
+		k *= movMultiplier(game.HomeScore-game.VisitingScore, winnerEloDiff)
+	}
+	shift := k * (homeActual - homeExpected)
+
+	ee.ratings[home] = homeRating + shift
+	ee.ratings[visitor] = visitingRating - shift
+	ee.history[home] = append(ee.history[home], EloPoint{Date: game.Date, Rating: ee.ratings[home]})
+	ee.history[visitor] = append(ee.history[visitor], EloPoint{Date: game.Date, Rating: ee.ratings[visitor]})
+	ee.games = append(ee.games, EloGame{
+		Date:                   game.Date,
+		HomeFranchise:          home,
+		VisitingFranchise:      visitor,
+		HomeScore:              game.HomeScore,
+		VisitingScore:          game.VisitingScore,
+		HomeResult:             homeResult,
+		HomeRating:             homeRating,
+		VisitingRating:         visitingRating,
+		HomeWinProbability:     homeExpected,
+		VisitingWinProbability: 1 - homeExpected,
+	})
+}
+
+// RatingOn returns the franchise's rating after its last game on or before
+// date.
+func (ee *eloEngine) RatingOn(franchise string, date time.Time) (float64, bool) {
+	history := ee.history[franchise]
+	i := sort.Search(len(history), func(i int) bool { return history[i].Date.After(date) })
+	if i == 0 {
+		return 0, false
+	}
+	return history[i-1].Rating, true
+}
+
+// Extremes returns the franchise's highest and lowest ratings from since on.
+func (ee *eloEngine) Extremes(franchise string, since int) (peak, trough EloPoint, ok bool) {
+	for _, point := range ee.history[franchise] {
+		if point.Date.Year() < since {
+			continue
+		}
+		if !ok || point.Rating > peak.Rating {
+			peak = point
+		}
+		if !ok || point.Rating < trough.Rating {
+			trough = point
+		}
+		ok = true
+	}
+	return peak, trough, ok
+}
+
+// Upsets returns the decided games from since on, biggest upset first.
+func (ee *eloEngine) Upsets(since int) []EloGame {
+	var upsets []EloGame
+	for _, game := range ee.games {
+		if game.Date.Year() >= since && game.WinnerProbability() >= 0 {
+			upsets = append(upsets, game)
+		}
+	}
+	sort.SliceStable(upsets, func(i, j int) bool {
+		return upsets[i].WinnerProbability() < upsets[j].WinnerProbability()
+	})
+	return upsets
+}
+
+// lastYearBefore returns the year of the franchise's last game on or before
+// date, so franchises that had folded can be left out of a date's ratings.
+func (ee *eloEngine) lastYearBefore(franchise string, date time.Time) int {
+	history := ee.history[franchise]
+	i := sort.Search(len(history), func(i int) bool { return history[i].Date.After(date) })
+	if i == 0 {
+		return 0
+	}
+	return history[i-1].Date.Year()
+}
+
+func (ee *eloEngine) Franchises() []string {
+	var franchises []string
+	for franchise := range ee.history {
+		franchises = append(franchises, franchise)
+	}
+	sort.Strings(franchises)
+	return franchises
+}
+
+// sortedRetrosheetGames reads every game log and returns the games in the
+// order they were played.
+func sortedRetrosheetGames(dir string) ([]*RetrosheetGame, error) {
+	var (
+		mu    sync.Mutex
+		games []*RetrosheetGame
+	)
+	err := ByRetrosheetGame(dir, func(game *RetrosheetGame) error {
+		mu.Lock()
+		games = append(games, game)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(games, func(i, j int) bool { return retrosheetGameLess(games[i], games[j]) })
+	return games, nil
+}
+
+// eloCmd represents the elo command
+var eloCmd = &cobra.Command{
+	Use:   "elo",
+	Short: "Elo ratings for every franchise since 1871",
+	Long: `Runs an Elo rating system over every game in the Retrosheet game logs.
+
+Inputs:
+
+k: The K-factor, how far a single game moves a rating.
+home-advantage: Rating points added to the home team when predicting a game.
+mov: Scale each update by the margin of victory.
+regression: Fraction of the distance to the initial rating given back between seasons.
+franchise: Print this franchise's rating after every game.
+date: Print every franchise's rating as of this date (YYYY-MM-DD).
+since: Only consider peaks, troughs and upsets from this year on.
+top: How many upsets to list.
+
+Without franchise or date, each franchise's peak and trough are printed along with the
+biggest upsets by pre-game win probability.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var config EloConfig
+		var err error
+		if config.InitialRating, err = cmd.Flags().GetFloat64("initial-rating"); err != nil {
+			return err
+		}
+		if config.K, err = cmd.Flags().GetFloat64("k"); err != nil {
+			return err
+		}
+		if config.HomeAdvantage, err = cmd.Flags().GetFloat64("home-advantage"); err != nil {
+			return err
+		}
+		if config.MarginOfVictory, err = cmd.Flags().GetBool("mov"); err != nil {
+			return err
+		}
+		if config.Regression, err = cmd.Flags().GetFloat64("regression"); err != nil {
+			return err
+		}
+		franchise, err := cmd.Flags().GetString("franchise")
+		if err != nil {
+			return err
+		}
+		dateFlag, err := cmd.Flags().GetString("date")
+		if err != nil {
+			return err
+		}
+		since, err := cmd.Flags().GetInt("since")
+		if err != nil {
+			return err
+		}
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+
+		franchiseConverter, err := getFranchiseConverter(filepath.Join(rsDataDir, "misc/CurrentNames.csv"))
+		if err != nil {
+			return err
+		}
+		games, err := sortedRetrosheetGames(rsDataDir)
+		if err != nil {
+			return err
+		}
+		engine := newEloEngine(config, franchiseConverter)
+		for _, game := range games {
+			engine.AddGame(game)
+		}
+
+		if franchise != "" {
+			for _, point := range engine.history[franchise] {
+				if point.Date.Year() >= since {
+					fmt.Printf("%s\t%.1f\n", point.Date.Format("2006-01-02"), point.Rating)
+				}
+			}
+			return nil
+		}
+
+		if dateFlag != "" {
+			date, err := time.Parse("2006-01-02", dateFlag)
+			if err != nil {
+				return err
+			}
+			type rated struct {
+				franchise string
+				rating    float64
+			}
+			var ratings []rated
+			for _, f := range engine.Franchises() {
+				if rating, ok := engine.RatingOn(f, date); ok && engine.lastYearBefore(f, date) >= date.Year()-1 {
+					ratings = append(ratings, rated{f, rating})
+				}
+			}
+			sort.Slice(ratings, func(i, j int) bool { return ratings[i].rating > ratings[j].rating })
+			for _, r := range ratings {
+				fmt.Printf("%s\t%.1f\n", r.franchise, r.rating)
+			}
+			return nil
+		}
+
+		fmt.Println("Peaks and troughs")
+		for _, f := range engine.Franchises() {
+			peak, trough, ok := engine.Extremes(f, since)
+			if !ok {
+				continue
+			}
+			fmt.Printf("%s\tpeak %.1f (%s)\ttrough %.1f (%s)\n", f, peak.Rating, peak.Date.Format("2006-01-02"), trough.Rating, trough.Date.Format("2006-01-02"))
+		}
+		fmt.Println()
+		fmt.Println("Biggest upsets")
+		upsets := engine.Upsets(since)
+		for i := 0; i < top && i < len(upsets); i++ {
+			game := upsets[i]
+			fmt.Printf("%s\t%s %d @ %s %d\twinner's chance %.1f%%\n", game.Date.Format("2006-01-02"), game.VisitingFranchise, game.VisitingScore, game.HomeFranchise, game.HomeScore, game.WinnerProbability()*100)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(eloCmd)
+	eloCmd.Flags().Float64("initial-rating", 1500, "rating every franchise starts with")
+	eloCmd.Flags().Float64("k", 4, "K-factor")
+	eloCmd.Flags().Float64("home-advantage", 24, "rating points added to the home team")
+	eloCmd.Flags().Bool("mov", false, "adjust updates for margin of victory")
+	eloCmd.Flags().Float64("regression", 0.33, "fraction of each rating regressed to the mean between seasons")
+	eloCmd.Flags().String("franchise", "", "print this franchise's rating after every game")
+	eloCmd.Flags().String("date", "", "print every franchise's rating as of this date (YYYY-MM-DD)")
+	eloCmd.Flags().Int("since", 0, "only report from this year on")
+	eloCmd.Flags().Int("top", 10, "number of upsets to list")
+}
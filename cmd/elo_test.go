@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEloEngine(t *testing.T) {
+	config := EloConfig{
+		InitialRating: 1500,
+		K:             20,
+		HomeAdvantage: 0,
+		Regression:    0.5,
+	}
+	engine := newEloEngine(config, FranchiseConverter{"BRO": "LAN"})
+
+	engine.AddGame(&RetrosheetGame{
+		Date:          time.Date(2000, time.April, 1, 0, 0, 0, 0, time.UTC),
+		VisitingTeam:  "SFN",
+		HomeTeam:      "LAN",
+		VisitingScore: 1,
+		HomeScore:     2,
+	})
+	assert.InDelta(t, 1510, engine.ratings["LAN"], 0.001)
+	assert.InDelta(t, 1490, engine.ratings["SFN"], 0.001)
+
+	// BRO is converted to the LAN franchise, and the new season pulls both
+	// ratings halfway back to 1500 before the game.
+	engine.AddGame(&RetrosheetGame{
+		Date:          time.Date(2001, time.April, 1, 0, 0, 0, 0, time.UTC),
+		VisitingTeam:  "SFN",
+		HomeTeam:      "BRO",
+		VisitingScore: 3,
+		HomeScore:     3,
+	})
+	game := engine.games[1]
+	assert.Equal(t, "LAN", game.HomeFranchise)
+	assert.InDelta(t, 1505, game.HomeRating, 0.001)
+	assert.InDelta(t, 1495, game.VisitingRating, 0.001)
+	assert.Equal(t, -1.0, game.WinnerProbability())
+
+	rating, ok := engine.RatingOn("SFN", time.Date(2000, time.June, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.InDelta(t, 1490, rating, 0.001)
+	_, ok = engine.RatingOn("SFN", time.Date(1999, time.June, 1, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+
+	upsets := engine.Upsets(0)
+	assert.Len(t, upsets, 1)
+	assert.InDelta(t, 0.5, upsets[0].WinnerProbability(), 0.001)
+}
+
+func TestEloForfeitWinner(t *testing.T) {
+	engine := newEloEngine(EloConfig{InitialRating: 1500, K: 20, HomeAdvantage: 100}, FranchiseConverter{})
+	// The home team led on the field but forfeited, so the visitors won.
+	engine.AddGame(&RetrosheetGame{
+		Date:          time.Date(1977, time.June, 4, 0, 0, 0, 0, time.UTC),
+		VisitingTeam:  "SFN",
+		HomeTeam:      "LAN",
+		VisitingScore: 2,
+		HomeScore:     5,
+		Forfeit:       ForfeitToVisitor,
+	})
+	game := engine.games[0]
+	assert.Equal(t, Loss, game.HomeResult)
+	assert.Equal(t, game.VisitingWinProbability, game.WinnerProbability())
+	assert.Greater(t, engine.ratings["SFN"], 1500.0)
+}
+
+func TestMOVMultiplier(t *testing.T) {
+	assert.InDelta(t, 0.693, movMultiplier(1, 0), 0.001)
+	assert.Equal(t, movMultiplier(5, 0), movMultiplier(-5, 0))
+	// Favorites winning big move less than underdogs winning big.
+	assert.Less(t, movMultiplier(5, 200), movMultiplier(5, -200))
+}
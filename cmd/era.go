@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type Era struct {
+	Name  string
+	Start int
+	End   int
+}
+
+// eras are the conventional eras of major league history, used to group
+// analyses whose numbers shift as the game changed.
+var eras = []Era{
+	{Name: "19th Century", Start: 1871, End: 1900},
+	{Name: "Dead Ball", Start: 1901, End: 1919},
+	{Name: "Live Ball", Start: 1920, End: 1941},
+	{Name: "Integration", Start: 1942, End: 1960},
+	{Name: "Expansion", Start: 1961, End: 1976},
+	{Name: "Free Agency", Start: 1977, End: 1993},
+	{Name: "Long Ball", Start: 1994, End: 2005},
+	{Name: "Modern", Start: 2006, End: 9999},
+}
+
+func eraForYear(year int) Era {
+	for _, era := range eras {
+		if year >= era.Start && year <= era.End {
+			return era
+		}
+	}
+	return eras[0]
+}
+
+// periodLabel groups a year by era, decade or single year. Labels sort in
+// chronological order.
+func periodLabel(by string, year int) (string, error) {
+	switch by {
+	case "era":
+		era := eraForYear(year)
+		return fmt.Sprintf("%d %s", era.Start, era.Name), nil
+	case "decade":
+		return fmt.Sprintf("%ds", year/10*10), nil
+	case "year":
+		return strconv.Itoa(year), nil
+	}
+	return "", fmt.Errorf("unknown grouping %q, expected era, decade or year", by)
+}
@@ -0,0 +1,209 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// HalfInning is the score at the end of a half inning.
+type HalfInning struct {
+	Inning        int
+	Bottom        bool
+	VisitingScore int
+	HomeScore     int
+}
+
+func (hi HalfInning) String() string {
+	half := "Top"
+	if hi.Bottom {
+		half = "Bot"
+	}
+	return fmt.Sprintf("%s %d", half, hi.Inning)
+}
+
+// lead is positive when the home team leads and negative when the visitors
+// do.
+func (hi HalfInning) lead() int {
+	return hi.HomeScore - hi.VisitingScore
+}
+
+type GameProgression struct {
+	HalfInnings []HalfInning
+
+	// LeadChanges counts the times one team took the lead from the other,
+	// whether directly or after a tie.
+	LeadChanges int
+	// Ties counts the times a game with a leader was tied back up.
+	Ties int
+
+	LargestVisitingLead int
+	LargestHomeLead     int
+
+	// Decisive is the half inning the winner took the lead for good. It is
+	// the zero HalfInning for ties.
+	Decisive HalfInning
+
+	// HomeBattedInFinal is false when the home team led after the top of the
+	// final inning and didn't bat, the "x" in a line score.
+	HomeBattedInFinal bool
+}
+
+// gameProgression combines both teams' runs by inning into the score after
+// each half inning. The home line score is one inning short when the home
+// team didn't bat in the final inning.
+func gameProgression(visiting, home []int) GameProgression {
+	gp := GameProgression{HomeBattedInFinal: len(home) >= len(visiting)}
+	var state HalfInning
+	addHalf := func(inning int, bottom bool, runs int) {
+		state.Inning = inning
+		state.Bottom = bottom
+		if bottom {
+			state.HomeScore += runs
+		} else {
+			state.VisitingScore += runs
+		}
+		gp.HalfInnings = append(gp.HalfInnings, state)
+	}
+	for i := range visiting {
+		addHalf(i+1, false, visiting[i])
+		if i < len(home) {
+			addHalf(i+1, true, home[i])
+		}
+	}
+
+	var leader, previous int // sign of the lead: 1 home, -1 visitors, 0 tied
+	for _, half := range gp.HalfInnings {
+		lead := half.lead()
+		if lead > gp.LargestHomeLead {
+			gp.LargestHomeLead = lead
+		}
+		if -lead > gp.LargestVisitingLead {
+			gp.LargestVisitingLead = -lead
+		}
+
+		current := sign(lead)
+		if current != 0 && leader != 0 && current != leader {
+			gp.LeadChanges++
+		}
+		if current == 0 && previous != 0 {
+			gp.Ties++
+		}
+		if current != 0 && current != previous {
+			gp.Decisive = half
+		}
+		if current != 0 {
+			leader = current
+		}
+		previous = current
+	}
+	if previous == 0 {
+		gp.Decisive = HalfInning{}
+	}
+	return gp
+}
+
+func sign(n int) int {
+	if n > 0 {
+		return 1
+	}
+	if n < 0 {
+		return -1
+	}
+	return 0
+}
+
+// Progression reconstructs the game's score after each half inning from its
+// line scores.
+func (rg RetrosheetGame) Progression() (GameProgression, error) {
+	visiting, home, err := rg.LineScores()
+	if err != nil {
+		return GameProgression{}, err
+	}
+	if !visiting.Complete() || !home.Complete() {
+		return GameProgression{}, fmt.Errorf("%s %s @ %s: line score unknown", rg.Date.Format("2006-01-02"), rg.VisitingTeam, rg.HomeTeam)
+	}
+	return gameProgression(visiting.Runs(), home.Runs()), nil
+}
+
+// gameStateCmd represents the gameState command
+var gameStateCmd = &cobra.Command{
+	Use:   "gameState",
+	Short: "Show a game's score after every half inning",
+	Long: `Reconstructs a game from its line scores: the score after each half inning, lead changes,
+ties, the largest lead for each team and when the winner took the lead for good.
+
+Inputs:
+
+date: The date of the game (YYYY-MM-DD).
+home-team: The home team's Retrosheet code. Every game it hosted that day is shown.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dateFlag, err := cmd.Flags().GetString("date")
+		if err != nil {
+			return err
+		}
+		homeTeam, err := cmd.Flags().GetString("home-team")
+		if err != nil {
+			return err
+		}
+		date, err := time.Parse("2006-01-02", dateFlag)
+		if err != nil {
+			return err
+		}
+
+		var (
+			mu    sync.Mutex
+			games []*RetrosheetGame
+		)
+		err = ByRetrosheetGame(rsDataDir, func(game *RetrosheetGame) error {
+			if game.Date.Equal(date) && game.HomeTeam == homeTeam {
+				mu.Lock()
+				games = append(games, game)
+				mu.Unlock()
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(games) == 0 {
+			return fmt.Errorf("no game found for %s on %s", homeTeam, dateFlag)
+		}
+		sort.Slice(games, func(i, j int) bool { return retrosheetGameLess(games[i], games[j]) })
+
+		for _, game := range games {
+			gp, err := game.Progression()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s %d @ %s %d\n", game.VisitingTeam, game.VisitingScore, game.HomeTeam, game.HomeScore)
+			for _, half := range gp.HalfInnings {
+				fmt.Printf("%s\t%d-%d\n", half, half.VisitingScore, half.HomeScore)
+			}
+			fmt.Println("Lead changes:", gp.LeadChanges)
+			fmt.Println("Ties:", gp.Ties)
+			fmt.Printf("Largest lead: %s %d, %s %d\n", game.VisitingTeam, gp.LargestVisitingLead, game.HomeTeam, gp.LargestHomeLead)
+			if gp.Decisive.Inning > 0 {
+				fmt.Println("Winner led for good from:", gp.Decisive)
+			}
+			fmt.Println("Home team batted in final inning:", gp.HomeBattedInFinal)
+			fmt.Println()
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gameStateCmd)
+	gameStateCmd.Flags().String("date", "", "date of the game (YYYY-MM-DD)")
+	gameStateCmd.Flags().String("home-team", "", "home team's Retrosheet code")
+	gameStateCmd.MarkFlagRequired("date")
+	gameStateCmd.MarkFlagRequired("home-team")
+}
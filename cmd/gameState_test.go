@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameProgression(t *testing.T) {
+	tests := []struct {
+		name                string
+		visiting            []int
+		home                []int
+		expectedLeadChanges int
+		expectedTies        int
+		expectedVisitorLead int
+		expectedHomeLead    int
+		expectedDecisive    HalfInning
+		expectedHomeBatted  bool
+	}{
+		{
+			name:                "home team didn't bat in the ninth",
+			visiting:            []int{0, 0, 0, 1, 0, 0, 0, 0, 0},
+			home:                []int{0, 0, 0, 0, 0, 0, 5, 2},
+			expectedLeadChanges: 1,
+			expectedVisitorLead: 1,
+			expectedHomeLead:    6,
+			expectedDecisive:    HalfInning{Inning: 7, Bottom: true, VisitingScore: 1, HomeScore: 5},
+		},
+		{
+			name:                "back and forth walk-off",
+			visiting:            []int{1, 0, 2, 0, 0, 0, 0, 0, 0},
+			home:                []int{0, 2, 0, 0, 1, 0, 0, 0, 1},
+			expectedLeadChanges: 3,
+			expectedTies:        1,
+			expectedVisitorLead: 1,
+			expectedHomeLead:    1,
+			expectedDecisive:    HalfInning{Inning: 9, Bottom: true, VisitingScore: 3, HomeScore: 4},
+			expectedHomeBatted:  true,
+		},
+		{
+			name:                "wire to wire",
+			visiting:            []int{2, 0, 0, 0, 0, 0, 0, 0, 0},
+			home:                []int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			expectedVisitorLead: 2,
+			expectedDecisive:    HalfInning{Inning: 1, VisitingScore: 2},
+			expectedHomeBatted:  true,
+		},
+		{
+			name:                "called with the score tied",
+			visiting:            []int{0, 1, 0, 0, 0},
+			home:                []int{0, 0, 1, 0, 0},
+			expectedTies:        1,
+			expectedVisitorLead: 1,
+			expectedHomeBatted:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gp := gameProgression(test.visiting, test.home)
+			assert.Len(t, gp.HalfInnings, len(test.visiting)+len(test.home))
+			assert.Equal(t, test.expectedLeadChanges, gp.LeadChanges)
+			assert.Equal(t, test.expectedTies, gp.Ties)
+			assert.Equal(t, test.expectedVisitorLead, gp.LargestVisitingLead)
+			assert.Equal(t, test.expectedHomeLead, gp.LargestHomeLead)
+			assert.Equal(t, test.expectedDecisive, gp.Decisive)
+			assert.Equal(t, test.expectedHomeBatted, gp.HomeBattedInFinal)
+		})
+	}
+}
+
+func TestRetrosheetGameProgression(t *testing.T) {
+	game := RetrosheetGame{VisitingScore: 1, HomeScore: 7, VisitingLineScore: "001000000", HomeLineScore: "00000052x"}
+	gp, err := game.Progression()
+	require.NoError(t, err)
+	assert.False(t, gp.HomeBattedInFinal)
+	last := gp.HalfInnings[len(gp.HalfInnings)-1]
+	assert.Equal(t, HalfInning{Inning: 9, VisitingScore: 1, HomeScore: 7}, last)
+}
@@ -5,39 +5,235 @@ package cmd
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 )
 
-// generateDataCmd represents the generateData command
-var generateDataCmd = &cobra.Command{
-	Use:   "generateData",
-	Short: "Generate test data.",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		const (
-			numTeams    = 30
-			numSeasons  = 150
-			numMinGames = 150
-			numMaxGames = 162
-		)
+var defaultGeneratedTeams = []string{
+	"ARI",
+	"ATL",
+	"BAL",
+	"BOS",
+	"CHC",
+	"CHW",
+	"CIN",
+	"CLE",
+	"COL",
+	"DET",
+	"FLA",
+	"HOU",
+	"KAN",
+	"LAA",
+	"LAD",
+	"MIL",
+	"MIN",
+	"NYM",
+	"NYY",
+	"OAK",
+	"PHI",
+	"PIT",
+	"SD",
+	"SF",
+	"SEA",
+	"STL",
+	"TB",
+	"TEX",
+	"TOR",
+	"WAS",
+}
 
-		outFilePath, err := cmd.Flags().GetString("out-file")
-		if err != nil {
-			return err
+type generatorConfig struct {
+	numTeams    int
+	numSeasons  int
+	endYear     int
+	numMinGames int
+	numMaxGames int
+
+	// strengthStdDev is the spread of true team winning percentages around
+	// .500.
+	strengthStdDev float64
+
+	// homeAdvantage is added to the home team's chance of winning.
+	homeAdvantage float64
+
+	// streakiness is added to a team's chance of winning after a win, and
+	// subtracted after a loss.
+	streakiness float64
+}
+
+// seasonDays is how many days a generated season may span, from its opening
+// day of April 1 through December 31, so every game falls in its year.
+const seasonDays = 275
+
+// scheduleDays bounds how many days generateSeason takes to schedule the most
+// games. With an odd number of teams one sits out each day, which stretches
+// the schedule by a game in every numTeams-1.
+func (c generatorConfig) scheduleDays() int {
+	if c.numTeams%2 == 0 {
+		return c.numMaxGames
+	}
+	return (c.numMaxGames*c.numTeams+c.numTeams-2)/(c.numTeams-1) + 1
+}
+
+func (c generatorConfig) validate() error {
+	if c.numTeams < 2 {
+		return errors.New("at least two teams are needed")
+	}
+	if c.numSeasons < 1 {
+		return errors.New("at least one season is needed")
+	}
+	if c.numMinGames < 1 {
+		return errors.New("min-games must be at least 1")
+	}
+	if c.numMinGames > c.numMaxGames {
+		return errors.New("min-games must not be greater than max-games")
+	}
+	if c.scheduleDays() > seasonDays {
+		return fmt.Errorf("max-games %d doesn't fit between April 1 and December 31 with %d teams", c.numMaxGames, c.numTeams)
+	}
+	return nil
+}
+
+type generatedGame struct {
+	day                int
+	home               string
+	visitor            string
+	homeGameNumber     int
+	visitingGameNumber int
+	homeScore          int
+	visitingScore      int
+}
+
+type generatedSeason struct {
+	year  int
+	games []generatedGame
+}
+
+func generatedTeams(numTeams int) []string {
+	teams := make([]string, 0, numTeams)
+	for i := 0; i < numTeams; i++ {
+		if i < len(defaultGeneratedTeams) {
+			teams = append(teams, defaultGeneratedTeams[i])
+		} else {
+			teams = append(teams, fmt.Sprintf("T%02d", i+1))
 		}
+	}
+	return teams
+}
 
-		f, err := os.Create(outFilePath)
-		if err != nil {
-			return err
+// log5 is the chance a team with true winning percentage a beats a team
+// with true winning percentage b.
+func log5(a, b float64) float64 {
+	return a * (1 - b) / (a*(1-b) + b*(1-a))
+}
+
+func clampProbability(p float64) float64 {
+	return math.Max(0.01, math.Min(0.99, p))
+}
+
+// poisson draws from a Poisson distribution using Knuth's method, which is
+// fine for the small means of runs scored.
+func poisson(r *rand.Rand, mean float64) int {
+	limit := math.Exp(-mean)
+	k := 0
+	p := r.Float64()
+	for p > limit {
+		k++
+		p *= r.Float64()
+	}
+	return k
+}
+
+// generateSeason builds a schedule where every game has a winner and a loser.
+// Each day teams are paired at random until every team has played the
+// season's number of games.
+func generateSeason(r *rand.Rand, config generatorConfig, teams []string, year int) generatedSeason {
+	numGames := config.numMinGames + r.Intn(config.numMaxGames-config.numMinGames+1)
+
+	strengths := make(map[string]float64, len(teams))
+	for _, team := range teams {
+		strengths[team] = math.Max(0.2, math.Min(0.8, 0.5+r.NormFloat64()*config.strengthStdDev))
+	}
+	gamesPlayed := make(map[string]int, len(teams))
+	lastResult := make(map[string]float64, len(teams)) // 1 after a win, -1 after a loss
+
+	season := generatedSeason{year: year}
+	for day := 0; ; day++ {
+		var available []string
+		for _, team := range teams {
+			if gamesPlayed[team] < numGames {
+				available = append(available, team)
+			}
+		}
+		if len(available) < 2 {
+			break
+		}
+		// With an odd number of teams the one with the most games sits out, so
+		// every team ends within a game of the others.
+		r.Shuffle(len(available), func(i, j int) { available[i], available[j] = available[j], available[i] })
+		if len(available)%2 == 1 {
+			sort.SliceStable(available, func(i, j int) bool { return gamesPlayed[available[i]] < gamesPlayed[available[j]] })
+			available = available[:len(available)-1]
+			r.Shuffle(len(available), func(i, j int) { available[i], available[j] = available[j], available[i] })
+		}
+
+		for i := 0; i+1 < len(available); i += 2 {
+			home, visitor := available[i], available[i+1]
+			p := log5(strengths[home], strengths[visitor]) + config.homeAdvantage
+			p += config.streakiness * (lastResult[home] - lastResult[visitor])
+			homeWon := r.Float64() < clampProbability(p)
+
+			loserScore := poisson(r, 3.5)
+			winnerScore := loserScore + 1 + poisson(r, 2)
+			gamesPlayed[home]++
+			gamesPlayed[visitor]++
+			game := generatedGame{
+				day:                day,
+				home:               home,
+				visitor:            visitor,
+				homeGameNumber:     gamesPlayed[home],
+				visitingGameNumber: gamesPlayed[visitor],
+			}
+			if homeWon {
+				game.homeScore, game.visitingScore = winnerScore, loserScore
+				lastResult[home], lastResult[visitor] = 1, -1
+			} else {
+				game.homeScore, game.visitingScore = loserScore, winnerScore
+				lastResult[home], lastResult[visitor] = -1, 1
+			}
+			season.games = append(season.games, game)
 		}
-		defer f.Close()
+	}
+	return season
+}
 
-		csvWriter := csv.NewWriter(f)
+// results returns each team's W/L sequence in game order.
+func (gs generatedSeason) results() map[string][]string {
+	results := make(map[string][]string)
+	for _, game := range gs.games {
+		if game.homeScore > game.visitingScore {
+			results[game.home] = append(results[game.home], "W")
+			results[game.visitor] = append(results[game.visitor], "L")
+		} else {
+			results[game.home] = append(results[game.home], "L")
+			results[game.visitor] = append(results[game.visitor], "W")
+		}
+	}
+	return results
+}
+
+func writeGeneratedCSV(outFilePath string, seasons []generatedSeason, teams []string, numMaxGames int) error {
+	return writeCSVFile(outFilePath, func(csvWriter *csv.Writer) error {
 		headers := []string{"Year", "Team"}
 		for i := 0; i < numMaxGames; i++ {
 			headers = append(headers, fmt.Sprintf("Game %d", i+1))
@@ -46,78 +242,230 @@ var generateDataCmd = &cobra.Command{
 			return err
 		}
 
-		rand.Seed(time.Now().Unix())
-
-		diffGames := numMaxGames - numMinGames
-
-		teams := []string{
-			"ARI",
-			"ATL",
-			"BAL",
-			"BOS",
-			"CHC",
-			"CHW",
-			"CIN",
-			"CLE",
-			"COL",
-			"DET",
-			"FLA",
-			"HOU",
-			"KAN",
-			"LAA",
-			"LAD",
-			"MIL",
-			"MIN",
-			"NYM",
-			"NYY",
-			"OAK",
-			"PHI",
-			"PIT",
-			"SD",
-			"SF",
-			"SEA",
-			"STL",
-			"TB",
-			"TEX",
-			"TOR",
-			"WAS",
-		}
-
-		for season := 2022 - numSeasons; season <= 2022; season++ {
+		for _, season := range seasons {
+			results := season.results()
 			for _, team := range teams {
-				modifier := rand.Intn(diffGames + 1)
-				games := numMinGames + modifier
-				data := make([]string, 2, games+2)
-				data[0] = strconv.Itoa(season)
+				data := make([]string, 2, numMaxGames+2)
+				data[0] = strconv.Itoa(season.year)
 				data[1] = team
-				for game := 1; game <= numMaxGames; game++ {
-					data = append(data, getGameResult(game, games))
+				for game := 0; game < numMaxGames; game++ {
+					if game < len(results[team]) {
+						data = append(data, results[team][game])
+					} else {
+						data = append(data, "")
+					}
 				}
 				if err := csvWriter.Write(data); err != nil {
-					return fmt.Errorf("failed to write data for season %d and team %s", season, team)
+					return fmt.Errorf("failed to write data for season %d and team %s", season.year, team)
 				}
 			}
 		}
+		return nil
+	})
+}
 
-		csvWriter.Flush()
+// lineScore spreads runs at random across innings in the Retrosheet line
+// score format, where double-digit innings are wrapped in parentheses.
+func lineScore(r *rand.Rand, runs, innings int) string {
+	byInning := make([]int, innings)
+	for i := 0; i < runs; i++ {
+		byInning[r.Intn(innings)]++
+	}
+	var sb strings.Builder
+	for _, inningRuns := range byInning {
+		if inningRuns >= 10 {
+			fmt.Fprintf(&sb, "(%d)", inningRuns)
+		} else {
+			sb.WriteString(strconv.Itoa(inningRuns))
+		}
+	}
+	return sb.String()
+}
 
-		return csvWriter.Error()
-	},
+// retrosheetRecord renders a game as a 161 field Retrosheet game log row,
+// filling in the fields this repo reads and leaving the rest blank.
+func retrosheetRecord(r *rand.Rand, game generatedGame, year int) []string {
+	date := time.Date(year, time.April, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, game.day)
+	record := make([]string, 161)
+	record[0] = date.Format("20060102")
+	record[1] = "0"
+	record[2] = date.Format("Mon")
+	record[3] = game.visitor
+	record[4] = "ML"
+	record[5] = strconv.Itoa(game.visitingGameNumber)
+	record[6] = game.home
+	record[7] = "ML"
+	record[8] = strconv.Itoa(game.homeGameNumber)
+	record[9] = strconv.Itoa(game.visitingScore)
+	record[10] = strconv.Itoa(game.homeScore)
+	record[12] = "N"
+	record[16] = game.home + "01"
+	record[19] = lineScore(r, game.visitingScore, 9)
+	if game.homeScore > game.visitingScore {
+		// The home team led after the top of the ninth and didn't bat.
+		record[11] = "51"
+		record[20] = lineScore(r, game.homeScore, 8) + "x"
+	} else {
+		record[11] = "54"
+		record[20] = lineScore(r, game.homeScore, 9)
+	}
+	return record
 }
 
-func init() {
-	rootCmd.AddCommand(generateDataCmd)
-	generateDataCmd.Flags().String("out-file", "", "path to output CSV")
-	generateDataCmd.MarkFlagRequired("out-file")
+func writeGeneratedRetrosheet(r *rand.Rand, outDirPath string, seasons []generatedSeason, teams []string) error {
+	gameDir := filepath.Join(outDirPath, "games")
+	miscDir := filepath.Join(outDirPath, "misc")
+	for _, dir := range []string{gameDir, miscDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	for _, season := range seasons {
+		if err := writeCSVFile(filepath.Join(gameDir, fmt.Sprintf("gl%d.txt", season.year)), func(csvWriter *csv.Writer) error {
+			for _, game := range season.games {
+				if err := csvWriter.Write(retrosheetRecord(r, game, season.year)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Every generated team is its own franchise in its own league.
+	return writeCSVFile(filepath.Join(miscDir, "CurrentNames.csv"), func(csvWriter *csv.Writer) error {
+		for _, team := range teams {
+			record := []string{team, team, "ML", "", team, team, "", fmt.Sprintf("1/1/%d", seasons[0].year), "", team, ""}
+			if err := csvWriter.Write(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func getGameResult(game, games int) string {
-	if game > games {
-		return ""
+func writeCSVFile(path string, write func(*csv.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
 	}
-	win := rand.Int() % 2
-	if win == 1 {
-		return "W"
+	defer f.Close()
+
+	csvWriter := csv.NewWriter(f)
+	if err := write(csvWriter); err != nil {
+		return err
 	}
-	return "L"
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// generateDataCmd represents the generateData command
+var generateDataCmd = &cobra.Command{
+	Use:   "generateData",
+	Short: "Generate test data.",
+	Long: `Generates fake seasons where every win for one team is a loss for its opponent.
+
+Each team-season gets a true winning percentage drawn from a normal distribution around .500,
+and games are decided by log5 plus home advantage and streakiness.
+
+Inputs:
+
+format: "csv" writes the wide W/L CSV compare reads to out-file. "retrosheet" writes
+Retrosheet-style game logs and a CurrentNames.csv under out-dir, laid out like cmd/rs_data.
+Pass out-dir to any command's --data-dir flag to analyze the generated logs.
+max-games: Seasons open on April 1 with a game a day and must end by December 31, so at most
+275 games, and fewer with an odd number of teams since one sits out each day.
+seed: Seed for the random generator. The same seed and flags produce the same data.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var config generatorConfig
+		var err error
+		if config.numTeams, err = cmd.Flags().GetInt("teams"); err != nil {
+			return err
+		}
+		if config.numSeasons, err = cmd.Flags().GetInt("seasons"); err != nil {
+			return err
+		}
+		if config.endYear, err = cmd.Flags().GetInt("end-year"); err != nil {
+			return err
+		}
+		if config.numMinGames, err = cmd.Flags().GetInt("min-games"); err != nil {
+			return err
+		}
+		if config.numMaxGames, err = cmd.Flags().GetInt("max-games"); err != nil {
+			return err
+		}
+		if config.strengthStdDev, err = cmd.Flags().GetFloat64("strength-stddev"); err != nil {
+			return err
+		}
+		if config.homeAdvantage, err = cmd.Flags().GetFloat64("home-advantage"); err != nil {
+			return err
+		}
+		if config.streakiness, err = cmd.Flags().GetFloat64("streakiness"); err != nil {
+			return err
+		}
+		seed, err := cmd.Flags().GetInt64("seed")
+		if err != nil {
+			return err
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		outFilePath, err := cmd.Flags().GetString("out-file")
+		if err != nil {
+			return err
+		}
+		outDirPath, err := cmd.Flags().GetString("out-dir")
+		if err != nil {
+			return err
+		}
+
+		if err := config.validate(); err != nil {
+			return err
+		}
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+			fmt.Fprintln(os.Stderr, "Using seed", seed)
+		}
+		r := rand.New(rand.NewSource(seed))
+
+		teams := generatedTeams(config.numTeams)
+		var seasons []generatedSeason
+		for year := config.endYear - config.numSeasons + 1; year <= config.endYear; year++ {
+			seasons = append(seasons, generateSeason(r, config, teams, year))
+		}
+
+		switch format {
+		case "csv":
+			if outFilePath == "" {
+				return errors.New("out-file is required for csv output")
+			}
+			return writeGeneratedCSV(outFilePath, seasons, teams, config.numMaxGames)
+		case "retrosheet":
+			if outDirPath == "" {
+				return errors.New("out-dir is required for retrosheet output")
+			}
+			return writeGeneratedRetrosheet(r, outDirPath, seasons, teams)
+		}
+		return fmt.Errorf("unknown format %q", format)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(generateDataCmd)
+	generateDataCmd.Flags().String("out-file", "", "path to output CSV")
+	generateDataCmd.Flags().String("out-dir", "", "directory to write Retrosheet-style game logs to")
+	generateDataCmd.Flags().String("format", "csv", "output format: csv or retrosheet")
+	generateDataCmd.Flags().Int64("seed", 0, "random seed; 0 seeds from the current time")
+	generateDataCmd.Flags().Int("teams", 30, "number of teams")
+	generateDataCmd.Flags().Int("seasons", 151, "number of seasons")
+	generateDataCmd.Flags().Int("end-year", 2022, "year of the last season")
+	generateDataCmd.Flags().Int("min-games", 150, "fewest games in a season")
+	generateDataCmd.Flags().Int("max-games", 162, "most games in a season")
+	generateDataCmd.Flags().Float64("strength-stddev", 0.06, "standard deviation of true team winning percentage")
+	generateDataCmd.Flags().Float64("home-advantage", 0.04, "added to the home team's chance of winning")
+	generateDataCmd.Flags().Float64("streakiness", 0, "added to a team's chance of winning after a win, subtracted after a loss")
 }
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSeason(t *testing.T) {
+	config := generatorConfig{
+		numTeams:       7,
+		numMinGames:    20,
+		numMaxGames:    20,
+		strengthStdDev: 0.1,
+		homeAdvantage:  0.04,
+		streakiness:    0.05,
+	}
+	teams := generatedTeams(config.numTeams)
+	season := generateSeason(rand.New(rand.NewSource(1)), config, teams, 2000)
+
+	var wins, losses int
+	for _, team := range teams {
+		results := season.results()[team]
+		// With an odd number of teams one team may be left a game short.
+		assert.GreaterOrEqual(t, len(results), 19, team)
+		assert.LessOrEqual(t, len(results), 20, team)
+		for _, result := range results {
+			if result == "W" {
+				wins++
+			} else {
+				losses++
+			}
+		}
+	}
+	assert.Equal(t, wins, losses)
+
+	again := generateSeason(rand.New(rand.NewSource(1)), config, teams, 2000)
+	assert.Equal(t, season, again)
+}
+
+func TestGeneratedRetrosheetRoundTrip(t *testing.T) {
+	config := generatorConfig{
+		numTeams:    4,
+		numMinGames: 10,
+		numMaxGames: 12,
+	}
+	r := rand.New(rand.NewSource(2))
+	teams := generatedTeams(config.numTeams)
+	seasons := []generatedSeason{
+		generateSeason(r, config, teams, 2000),
+		generateSeason(r, config, teams, 2001),
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, writeGeneratedRetrosheet(r, dir, seasons, teams))
+
+	teamsBySeason, err := GetTeamsBySeason(dir)
+	require.NoError(t, err)
+	for _, season := range seasons {
+		results := season.results()
+		for _, team := range teams {
+			s := teamsBySeason.m[team][season.year]
+			require.NotNil(t, s)
+			require.Len(t, s.Games, len(results[team]))
+			for i, game := range s.Games {
+				if results[team][i] == "W" {
+					assert.Equal(t, Win, game.Result)
+				} else {
+					assert.Equal(t, Loss, game.Result)
+				}
+			}
+		}
+	}
+}
+
+func TestGeneratorConfigValidate(t *testing.T) {
+	valid := generatorConfig{numTeams: 2, numSeasons: 1, numMinGames: 1, numMaxGames: 1}
+	assert.NoError(t, valid.validate())
+
+	tests := []func(c *generatorConfig){
+		func(c *generatorConfig) { c.numTeams = 1 },
+		func(c *generatorConfig) { c.numSeasons = 0 },
+		func(c *generatorConfig) { c.numMinGames = 0 },
+		func(c *generatorConfig) { c.numMinGames = 2 },
+		func(c *generatorConfig) { c.numMaxGames = 276 },
+		func(c *generatorConfig) { c.numTeams, c.numMaxGames = 3, 200 },
+	}
+	for i, change := range tests {
+		c := valid
+		change(&c)
+		assert.Error(t, c.validate(), "case %d", i)
+	}
+}
+
+func TestGeneratedDatesInSeasonYear(t *testing.T) {
+	for _, numTeams := range []int{2, 3, 5, 30} {
+		config := generatorConfig{numTeams: numTeams, numSeasons: 1, numMinGames: 1}
+		// The longest season validate allows.
+		for config.numMaxGames = 1; config.validate() == nil; config.numMaxGames++ {
+		}
+		config.numMaxGames--
+		config.numMinGames = config.numMaxGames
+
+		r := rand.New(rand.NewSource(3))
+		season := generateSeason(r, config, generatedTeams(numTeams), 2001)
+		for _, game := range season.games {
+			date, err := time.Parse("20060102", retrosheetRecord(r, game, season.year)[0])
+			require.NoError(t, err)
+			require.Equal(t, 2001, date.Year(), "%d teams, %d games, day %d", numTeams, config.numMaxGames, game.day)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// headToHeadIndex holds every franchise's games against each opponent in the
+// order they were played.
+type headToHeadIndex map[string]map[string][]TeamGame
+
+func newHeadToHeadIndex(btbs *ByTeamsBySeason) headToHeadIndex {
+	index := make(headToHeadIndex)
+	for franchise, seasons := range btbs.BySortedSeason() {
+		opponents := make(map[string][]TeamGame)
+		for _, season := range seasons {
+			for _, game := range season.Games {
+				if game.OpponentFranchise == franchise {
+					continue
+				}
+				opponents[game.OpponentFranchise] = append(opponents[game.OpponentFranchise], game)
+			}
+		}
+		index[franchise] = opponents
+	}
+	return index
+}
+
+type SeasonHeadToHead struct {
+	Year   int
+	Record SeasonRecord
+}
+
+// HeadToHead is a franchise's record against one opponent, all-time and by
+// season.
+type HeadToHead struct {
+	Franchise string
+	Opponent  string
+	Record    SeasonRecord
+	Seasons   []SeasonHeadToHead
+}
+
+func (index headToHeadIndex) headToHead(franchise, opponent string) HeadToHead {
+	h2h := HeadToHead{Franchise: franchise, Opponent: opponent}
+	for _, game := range index[franchise][opponent] {
+		year := game.Date.Year()
+		if len(h2h.Seasons) == 0 || h2h.Seasons[len(h2h.Seasons)-1].Year != year {
+			h2h.Seasons = append(h2h.Seasons, SeasonHeadToHead{Year: year})
+		}
+		addResult(&h2h.Record, game.Result)
+		addResult(&h2h.Seasons[len(h2h.Seasons)-1].Record, game.Result)
+	}
+	return h2h
+}
+
+// WinStreak is a run of consecutive wins.
+type WinStreak struct {
+	Wins  int
+	Start time.Time
+	End   time.Time
+}
+
+// longestWinStreak returns the longest run of wins among n results in the
+// order played, each of which at returns with its date. Anything but a win
+// ends a run.
+func longestWinStreak(n int, at func(i int) (Result, time.Time)) WinStreak {
+	var best, current WinStreak
+	for i := 0; i < n; i++ {
+		result, date := at(i)
+		if result != Win {
+			current = WinStreak{}
+			continue
+		}
+		if current.Wins == 0 {
+			current.Start = date
+		}
+		current.Wins++
+		current.End = date
+		if current.Wins > best.Wins {
+			best = current
+		}
+	}
+	return best
+}
+
+// rankStreaks sorts streaks, a slice of n elements whose WinStreak streak
+// returns, longest and then earliest first, and returns how many of them to
+// keep to list the top.
+func rankStreaks(streaks interface{}, n, top int, streak func(i int) WinStreak) int {
+	sort.Slice(streaks, func(i, j int) bool {
+		a, b := streak(i), streak(j)
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		return a.Start.Before(b.Start)
+	})
+	if n > top {
+		return top
+	}
+	return n
+}
+
+type HeadToHeadStreak struct {
+	Franchise string
+	Opponent  string
+	WinStreak
+}
+
+// longestStreaks returns the longest runs of consecutive wins by one
+// franchise over another, longest first. A tie ends a streak.
+func (index headToHeadIndex) longestStreaks(top int) []HeadToHeadStreak {
+	var streaks []HeadToHeadStreak
+	for franchise, opponents := range index {
+		for opponent, games := range opponents {
+			best := longestWinStreak(len(games), func(i int) (Result, time.Time) { return games[i].Result, games[i].Date })
+			if best.Wins > 0 {
+				streaks = append(streaks, HeadToHeadStreak{Franchise: franchise, Opponent: opponent, WinStreak: best})
+			}
+		}
+	}
+	return streaks[:rankStreaks(streaks, len(streaks), top, func(i int) WinStreak { return streaks[i].WinStreak })]
+}
+
+// lopsidedRivalries returns the all-time head-to-heads with at least minGames
+// decided games, most one-sided first. Each pair appears once, from the
+// leading franchise's side.
+func (index headToHeadIndex) lopsidedRivalries(minGames, top int) []HeadToHead {
+	var rivalries []HeadToHead
+	for franchise, opponents := range index {
+		for opponent := range opponents {
+			h2h := index.headToHead(franchise, opponent)
+			decided := h2h.Record.Wins + h2h.Record.Losses
+			if decided < minGames || h2h.Record.Wins < h2h.Record.Losses {
+				continue
+			}
+			if h2h.Record.Wins == h2h.Record.Losses && franchise > opponent {
+				continue
+			}
+			rivalries = append(rivalries, h2h)
+		}
+	}
+	sort.Slice(rivalries, func(i, j int) bool {
+		if rivalries[i].Record.Pct() != rivalries[j].Record.Pct() {
+			return rivalries[i].Record.Pct() > rivalries[j].Record.Pct()
+		}
+		return rivalries[i].Franchise+rivalries[i].Opponent < rivalries[j].Franchise+rivalries[j].Opponent
+	})
+	if len(rivalries) > top {
+		rivalries = rivalries[:top]
+	}
+	return rivalries
+}
+
+// writeHeadToHeadMatrix writes a season's head-to-head records as CSV, each
+// row franchise's record against each column franchise.
+func writeHeadToHeadMatrix(csvWriter *csv.Writer, seasons []*Season) error {
+	franchises := make([]string, len(seasons))
+	for i, season := range seasons {
+		franchises[i] = season.Franchise
+	}
+	if err := csvWriter.Write(append([]string{""}, franchises...)); err != nil {
+		return err
+	}
+	for _, season := range seasons {
+		records := make(map[string]*SeasonRecord)
+		for _, game := range season.Games {
+			record, ok := records[game.OpponentFranchise]
+			if !ok {
+				record = &SeasonRecord{}
+				records[game.OpponentFranchise] = record
+			}
+			addResult(record, game.Result)
+		}
+		row := []string{season.Franchise}
+		for _, opponent := range franchises {
+			cell := ""
+			if record, ok := records[opponent]; ok && opponent != season.Franchise {
+				cell = record.String()
+			}
+			row = append(row, cell)
+		}
+		if err := csvWriter.Write(row); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// headToHeadCmd represents the headToHead command
+var headToHeadCmd = &cobra.Command{
+	Use:   "headToHead",
+	Short: "Head-to-head records between franchises",
+	Long: `Analyses of how franchises have fared against each other, built from every game in the
+Retrosheet game logs. Franchises are identified by their Retrosheet code, e.g. SFN.`,
+}
+
+var headToHeadRecordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "All-time and per-season record of one franchise against another",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		franchise, err := cmd.Flags().GetString("franchise")
+		if err != nil {
+			return err
+		}
+		opponent, err := cmd.Flags().GetString("opponent")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		h2h := newHeadToHeadIndex(teamsBySeason).headToHead(franchise, opponent)
+		if len(h2h.Seasons) == 0 {
+			return fmt.Errorf("%s and %s never played", franchise, opponent)
+		}
+		fmt.Printf("%s vs %s: %s (%s)\n", franchise, opponent, h2h.Record, formatPct(h2h.Record.Pct()))
+		for _, season := range h2h.Seasons {
+			fmt.Printf("%d\t%s\n", season.Year, season.Record)
+		}
+		return nil
+	},
+}
+
+var headToHeadStreaksCmd = &cobra.Command{
+	Use:   "streaks",
+	Short: "Longest win streaks by one franchise over another",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		for _, streak := range newHeadToHeadIndex(teamsBySeason).longestStreaks(top) {
+			fmt.Printf("%s over %s\t%d\t%s to %s\n", streak.Franchise, streak.Opponent, streak.Wins, streak.Start.Format("2006-01-02"), streak.End.Format("2006-01-02"))
+		}
+		return nil
+	},
+}
+
+var headToHeadRivalriesCmd = &cobra.Command{
+	Use:   "rivalries",
+	Short: "The most lopsided all-time head-to-head records",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		minGames, err := cmd.Flags().GetInt("min-games")
+		if err != nil {
+			return err
+		}
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		for _, h2h := range newHeadToHeadIndex(teamsBySeason).lopsidedRivalries(minGames, top) {
+			fmt.Printf("%s vs %s\t%s\t%s\n", h2h.Franchise, h2h.Opponent, h2h.Record, formatPct(h2h.Record.Pct()))
+		}
+		return nil
+	},
+}
+
+var headToHeadMatrixCmd = &cobra.Command{
+	Use:   "matrix",
+	Short: "Every franchise's record against every other in a season, as CSV",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		seasons := teamsBySeason.SeasonsInYear(year)
+		if len(seasons) == 0 {
+			return fmt.Errorf("no games found for %d", year)
+		}
+		return writeHeadToHeadMatrix(csv.NewWriter(os.Stdout), seasons)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(headToHeadCmd)
+	headToHeadCmd.AddCommand(headToHeadRecordCmd, headToHeadStreaksCmd, headToHeadRivalriesCmd, headToHeadMatrixCmd)
+
+	headToHeadRecordCmd.Flags().String("franchise", "", "franchise whose record is shown")
+	headToHeadRecordCmd.Flags().String("opponent", "", "opponent franchise")
+	headToHeadRecordCmd.MarkFlagRequired("franchise")
+	headToHeadRecordCmd.MarkFlagRequired("opponent")
+
+	headToHeadStreaksCmd.Flags().Int("top", 10, "number of streaks to list")
+
+	headToHeadRivalriesCmd.Flags().Int("min-games", 500, "fewest decided games for a rivalry to count")
+	headToHeadRivalriesCmd.Flags().Int("top", 10, "number of rivalries to list")
+
+	headToHeadMatrixCmd.Flags().Int("year", 0, "season to export")
+	headToHeadMatrixCmd.MarkFlagRequired("year")
+}
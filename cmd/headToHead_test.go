@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadToHead(t *testing.T) {
+	teamsBySeason, err := GetTeamsBySeason("./test_data")
+	require.NoError(t, err)
+	index := newHeadToHeadIndex(teamsBySeason)
+
+	h2h := index.headToHead("SFN", "LAN")
+	assert.Equal(t, SeasonRecord{Wins: 2, Losses: 2}, h2h.Record)
+	assert.Equal(t, []SeasonHeadToHead{
+		{Year: 2000, Record: SeasonRecord{Wins: 1, Losses: 1}},
+		{Year: 2001, Record: SeasonRecord{Wins: 1, Losses: 1}},
+	}, h2h.Seasons)
+
+	streaks := index.longestStreaks(1)
+	assert.Equal(t, []HeadToHeadStreak{{
+		Franchise: "CHN",
+		Opponent:  "MIL",
+		WinStreak: WinStreak{
+			Wins:  3,
+			Start: time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
+		},
+	}}, streaks)
+
+	rivalries := index.lopsidedRivalries(4, 10)
+	require.Len(t, rivalries, 2)
+	assert.Equal(t, "CHN", rivalries[0].Franchise)
+	assert.Equal(t, SeasonRecord{Wins: 3, Losses: 1}, rivalries[0].Record)
+	// An even rivalry is listed once.
+	assert.Equal(t, "LAN", rivalries[1].Franchise)
+	assert.Equal(t, "SFN", rivalries[1].Opponent)
+}
+
+func TestWriteHeadToHeadMatrix(t *testing.T) {
+	teamsBySeason, err := GetTeamsBySeason("./test_data")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeHeadToHeadMatrix(csv.NewWriter(&buf), teamsBySeason.SeasonsInYear(2000)))
+	assert.Equal(t, `,CHN,LAN,MIL,SFN
+CHN,,,2-0,
+LAN,,,,1-1
+MIL,0-2,,,
+SFN,,1-1,,
+`, buf.String())
+}
+
+func TestLongestWinStreak(t *testing.T) {
+	results := []Result{Win, Win, Tie, Win, Win, Win, Loss, Win}
+	day := func(i int) time.Time { return time.Date(2001, time.May, 1+i, 0, 0, 0, 0, time.UTC) }
+	streak := longestWinStreak(len(results), func(i int) (Result, time.Time) { return results[i], day(i) })
+	assert.Equal(t, WinStreak{Wins: 3, Start: day(3), End: day(5)}, streak)
+	assert.Zero(t, longestWinStreak(0, nil))
+}
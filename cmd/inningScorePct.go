@@ -6,7 +6,7 @@ package cmd
 import (
 	"fmt"
 	"sort"
-	"sync"
+	"strconv"
 
 	"github.com/spf13/cobra"
 )
@@ -17,58 +17,89 @@ type inningOutscorePerSeason struct {
 	totalGames int
 }
 
+// Pct is the percentage of the season's games in which a team scored more in
+// one inning than its opponent did all game.
+func (s inningOutscorePerSeason) Pct() float64 {
+	return pct(s.weirdGames, s.totalGames)
+}
+
+// PctString is Pct to one decimal place, as both stdout and the HTML report
+// print it.
+func (s inningOutscorePerSeason) PctString() string {
+	return fmt.Sprintf("%.1f", s.Pct())
+}
+
 // inningScorePctCmd represents the inningScorePct command
 var inningScorePctCmd = &cobra.Command{
 	Use:   "inningScorePct",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
+	Short: "Percentage of games each year in which one inning outscored the opponent",
+	Long: `Prints, for each year, the percentage of games in which a team scored more runs in a single
+inning than its opponent scored in the whole game.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var mu sync.Mutex
-		allGames := map[int]inningOutscorePerSeason{}
-		err := ByRetrosheetGame("cmd/rs_data", func(game *RetrosheetGame) error {
-			homeLineScore, err := game.LineScoreProcessed(game.HomeLineScore)
-			if err != nil {
-				return err
-			}
-			visitingLineScore, err := game.LineScoreProcessed(game.VisitingLineScore)
-			if err != nil {
-				return err
-			}
-			mu.Lock()
-			season := allGames[game.Date.Year()]
-			season.totalGames++
-			if isWeirdGame(homeLineScore, game.VisitingScore) || isWeirdGame(visitingLineScore, game.HomeScore) {
-				fmt.Println("It's weird!", game.HomeLineScore, game.VisitingLineScore, game.HomeScore, game.VisitingScore)
-				season.weirdGames++
-			}
-			allGames[game.Date.Year()] = season
-			mu.Unlock()
-			return nil
-		})
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
 		if err != nil {
 			return err
 		}
-
-		var seasonsList []inningOutscorePerSeason
-		for year, details := range allGames {
-			details.season = year
-			seasonsList = append(seasonsList, details)
+		// Every game is in both teams' seasons, so count it once, from the
+		// home team's side.
+		seasonsList, err := seasonInningOutscores(newSeasonIndex(teamsBySeason).seasons, func(game TeamGame) bool { return game.IsHome })
+		if err != nil {
+			return err
 		}
-		sort.Slice(seasonsList, func(i, j int) bool {
-			return seasonsList[i].season < seasonsList[j].season
-		})
 		for _, season := range seasonsList {
-			fmt.Printf("%d\t%d\n", season.season, season.weirdGames*100/season.totalGames)
+			fmt.Printf("%d\t%s\n", season.season, season.PctString())
+		}
+		htmlPath, err := cmd.Flags().GetString("html")
+		if err != nil {
+			return err
+		}
+		if htmlPath != "" {
+			return writeReportFile(htmlPath, inningReport(seasonsList))
 		}
 		return nil
 	},
 }
 
+// seasonInningOutscores counts, by year, the games of the seasons keep
+// accepts in which either team scored more in one inning than its opponent
+// did all game.
+func seasonInningOutscores(seasons []*Season, keep func(TeamGame) bool) ([]inningOutscorePerSeason, error) {
+	byYear := map[int]*inningOutscorePerSeason{}
+	var years []int
+	for _, season := range seasons {
+		for _, game := range season.Games {
+			if !keep(game) {
+				continue
+			}
+			teamLineScore, err := ParseLineScore(game.TeamLineScore)
+			if err != nil {
+				return nil, err
+			}
+			opponentLineScore, err := ParseLineScore(game.OpponentLineScore)
+			if err != nil {
+				return nil, err
+			}
+			year := game.Date.Year()
+			counts, ok := byYear[year]
+			if !ok {
+				counts = &inningOutscorePerSeason{season: year}
+				byYear[year] = counts
+				years = append(years, year)
+			}
+			counts.totalGames++
+			if isWeirdGame(teamLineScore.Runs(), game.OpponentScore) || isWeirdGame(opponentLineScore.Runs(), game.TeamScore) {
+				counts.weirdGames++
+			}
+		}
+	}
+	sort.Ints(years)
+	outscores := make([]inningOutscorePerSeason, len(years))
+	for i, year := range years {
+		outscores[i] = *byYear[year]
+	}
+	return outscores, nil
+}
+
 func isWeirdGame(lineScore []int, oppoScore int) bool {
 	for _, score := range lineScore {
 		if score > oppoScore {
@@ -78,8 +109,29 @@ func isWeirdGame(lineScore []int, oppoScore int) bool {
 	return false
 }
 
+// inningReport charts and tabulates the percentage of games each year in
+// which a team scored more in one inning than its opponent did all game.
+func inningReport(seasons []inningOutscorePerSeason) Report {
+	chart := LineChart{XLabel: "Season", YLabel: "% of games", Series: []ChartSeries{{Label: "Games"}}}
+	table := &ReportTable{Columns: []string{"Season", "Games", "Outscored in an inning", "Pct"}}
+	for _, season := range seasons {
+		chart.Series[0].Points = append(chart.Series[0].Points, ChartPoint{X: float64(season.season), Y: season.Pct()})
+		table.Rows = append(table.Rows, []string{
+			strconv.Itoa(season.season),
+			strconv.Itoa(season.totalGames),
+			strconv.Itoa(season.weirdGames),
+			season.PctString(),
+		})
+	}
+	section := chartSection("By season", chart)
+	section.Notes = "Games in which either team scored more runs in a single inning than its opponent scored in the whole game."
+	section.Table = table
+	return Report{Title: "Games outscored in one inning", Sections: []ReportSection{section}}
+}
+
 func init() {
 	rootCmd.AddCommand(inningScorePctCmd)
+	inningScorePctCmd.Flags().String("html", "", "also write the percentages to this HTML report file")
 
 	// Here you will define your flags and configuration settings.
 
@@ -0,0 +1,560 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// lineScoreGame is a game whose line scores parsed and add up to the final
+// score.
+type lineScoreGame struct {
+	Game     *RetrosheetGame
+	Period   string
+	Visiting []int
+	Home     []int // one inning short when the home team didn't bat in the last
+}
+
+// Innings is the number of innings played.
+func (lsg lineScoreGame) Innings() int {
+	return len(lsg.Visiting)
+}
+
+// progression reconstructs the game's score after each half inning.
+func (lsg lineScoreGame) progression() GameProgression {
+	return gameProgression(lsg.Visiting, lsg.Home)
+}
+
+func sumRuns(runs []int) int {
+	var total int
+	for _, r := range runs {
+		total += r
+	}
+	return total
+}
+
+// loadLineScoreGames reads every game with a usable line score, grouping
+// each by the given period.
+func loadLineScoreGames(dir, by string) ([]lineScoreGame, error) {
+	if _, err := periodLabel(by, 0); err != nil {
+		return nil, err
+	}
+	var (
+		mu    sync.Mutex
+		games []lineScoreGame
+	)
+	err := ByRetrosheetGame(dir, func(game *RetrosheetGame) error {
+		visiting, home, err := game.LineScores()
+		var mismatch *LineScoreMismatchError
+		if errors.As(err, &mismatch) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !visiting.Complete() || !home.Complete() {
+			return nil
+		}
+		period, _ := periodLabel(by, game.Date.Year())
+		mu.Lock()
+		games = append(games, lineScoreGame{Game: game, Period: period, Visiting: visiting.Runs(), Home: home.Runs()})
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(games, func(i, j int) bool { return retrosheetGameLess(games[i].Game, games[j].Game) })
+	return games, nil
+}
+
+// periods returns the distinct periods of games in order.
+func periods(games []lineScoreGame) []string {
+	var labels []string
+	seen := make(map[string]struct{})
+	for _, game := range games {
+		if _, ok := seen[game.Period]; !ok {
+			seen[game.Period] = struct{}{}
+			labels = append(labels, game.Period)
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+type InningRuns struct {
+	Period string
+	// Inning is 1-9, or 10 for every extra inning combined.
+	Inning      int
+	HalfInnings int
+	Runs        int
+	// Distribution counts half innings with 0, 1, 2, 3, 4 and 5 or more runs.
+	Distribution [6]int
+}
+
+func (ir InningRuns) RunsPerHalfInning() float64 {
+	if ir.HalfInnings == 0 {
+		return 0
+	}
+	return float64(ir.Runs) / float64(ir.HalfInnings)
+}
+
+func runsByInning(games []lineScoreGame) []InningRuns {
+	rows := make(map[string]*[10]InningRuns)
+	for _, game := range games {
+		byInning, ok := rows[game.Period]
+		if !ok {
+			byInning = &[10]InningRuns{}
+			for i := range byInning {
+				byInning[i] = InningRuns{Period: game.Period, Inning: i + 1}
+			}
+			rows[game.Period] = byInning
+		}
+		for _, lineScore := range [][]int{game.Visiting, game.Home} {
+			for i, runs := range lineScore {
+				row := &byInning[9]
+				if i < 9 {
+					row = &byInning[i]
+				}
+				row.HalfInnings++
+				row.Runs += runs
+				if runs > 5 {
+					runs = 5
+				}
+				row.Distribution[runs]++
+			}
+		}
+	}
+
+	var result []InningRuns
+	for _, period := range periods(games) {
+		result = append(result, rows[period][:]...)
+	}
+	return result
+}
+
+type FirstInning struct {
+	Period        string
+	Games         int
+	VisitorScored int
+	HomeScored    int
+	EitherScored  int
+	// OnlyOneScored counts games where exactly one team scored in the first,
+	// and OnlyOneScoredWon how many of those that team went on to win.
+	OnlyOneScored    int
+	OnlyOneScoredWon int
+}
+
+func firstInningScoring(games []lineScoreGame) []FirstInning {
+	rows := make(map[string]*FirstInning)
+	for _, game := range games {
+		if len(game.Visiting) == 0 || len(game.Home) == 0 {
+			continue
+		}
+		row, ok := rows[game.Period]
+		if !ok {
+			row = &FirstInning{Period: game.Period}
+			rows[game.Period] = row
+		}
+		row.Games++
+		visitorScored := game.Visiting[0] > 0
+		homeScored := game.Home[0] > 0
+		if visitorScored {
+			row.VisitorScored++
+		}
+		if homeScored {
+			row.HomeScored++
+		}
+		if visitorScored || homeScored {
+			row.EitherScored++
+		}
+		if visitorScored != homeScored {
+			row.OnlyOneScored++
+			if (visitorScored && game.Game.VisitorResult(OnFieldResult) == Win) || (homeScored && game.Game.HomeResult(OnFieldResult) == Win) {
+				row.OnlyOneScoredWon++
+			}
+		}
+	}
+
+	var result []FirstInning
+	for _, period := range periods(games) {
+		if row, ok := rows[period]; ok {
+			result = append(result, *row)
+		}
+	}
+	return result
+}
+
+type ComebackGame struct {
+	Date          string
+	VisitingTeam  string
+	HomeTeam      string
+	VisitingScore int
+	HomeScore     int
+	Winner        string
+	// Deficit is the most runs the winner trailed by.
+	Deficit int
+}
+
+type Comebacks struct {
+	Period string
+	Games  int
+	// ComebackWins counts games the winner trailed at some point.
+	ComebackWins int
+	// ThreeRunComebacks counts games the winner trailed by three or more.
+	ThreeRunComebacks int
+	Largest           ComebackGame
+}
+
+// largestDeficit returns the most runs the winner ever trailed by, or 0 for
+// ties and games the winner never trailed.
+func largestDeficit(game lineScoreGame) int {
+	gp := game.progression()
+	if game.Game.VisitingScore > game.Game.HomeScore {
+		return gp.LargestHomeLead
+	} else if game.Game.HomeScore > game.Game.VisitingScore {
+		return gp.LargestVisitingLead
+	}
+	return 0
+}
+
+func comebackGame(game lineScoreGame, deficit int) ComebackGame {
+	cg := ComebackGame{
+		Date:          game.Game.Date.Format("2006-01-02"),
+		VisitingTeam:  game.Game.VisitingTeam,
+		HomeTeam:      game.Game.HomeTeam,
+		VisitingScore: game.Game.VisitingScore,
+		HomeScore:     game.Game.HomeScore,
+		Deficit:       deficit,
+	}
+	if game.Game.HomeScore > game.Game.VisitingScore {
+		cg.Winner = game.Game.HomeTeam
+	} else {
+		cg.Winner = game.Game.VisitingTeam
+	}
+	return cg
+}
+
+// comebacks summarizes comeback wins by period and returns the top largest
+// comebacks overall.
+func comebacks(games []lineScoreGame, top int) ([]Comebacks, []ComebackGame) {
+	rows := make(map[string]*Comebacks)
+	var largest []ComebackGame
+	for _, game := range games {
+		row, ok := rows[game.Period]
+		if !ok {
+			row = &Comebacks{Period: game.Period}
+			rows[game.Period] = row
+		}
+		row.Games++
+		deficit := largestDeficit(game)
+		if deficit == 0 {
+			continue
+		}
+		row.ComebackWins++
+		if deficit >= 3 {
+			row.ThreeRunComebacks++
+		}
+		cg := comebackGame(game, deficit)
+		if deficit > row.Largest.Deficit {
+			row.Largest = cg
+		}
+		largest = append(largest, cg)
+	}
+
+	sort.SliceStable(largest, func(i, j int) bool { return largest[i].Deficit > largest[j].Deficit })
+	if len(largest) > top {
+		largest = largest[:top]
+	}
+	var result []Comebacks
+	for _, period := range periods(games) {
+		result = append(result, *rows[period])
+	}
+	return result, largest
+}
+
+type Walkoffs struct {
+	Period   string
+	Games    int
+	HomeWins int
+	Walkoffs int
+}
+
+// isWalkoff reports whether the home team won by taking the lead in the
+// bottom of the final inning.
+func isWalkoff(game lineScoreGame) bool {
+	if game.Game.HomeScore <= game.Game.VisitingScore {
+		return false
+	}
+	gp := game.progression()
+	if !gp.HomeBattedInFinal || len(gp.HalfInnings) == 0 {
+		return false
+	}
+	last := gp.HalfInnings[len(gp.HalfInnings)-1]
+	return gp.Decisive == last
+}
+
+func walkoffs(games []lineScoreGame) []Walkoffs {
+	rows := make(map[string]*Walkoffs)
+	for _, game := range games {
+		row, ok := rows[game.Period]
+		if !ok {
+			row = &Walkoffs{Period: game.Period}
+			rows[game.Period] = row
+		}
+		row.Games++
+		if game.Game.HomeScore > game.Game.VisitingScore {
+			row.HomeWins++
+		}
+		if isWalkoff(game) {
+			row.Walkoffs++
+		}
+	}
+
+	var result []Walkoffs
+	for _, period := range periods(games) {
+		result = append(result, *rows[period])
+	}
+	return result
+}
+
+type ExtraInnings struct {
+	Period           string
+	Games            int
+	ExtraInningGames int
+	HomeWins         int
+	VisitorWins      int
+	Ties             int
+	LongestInnings   int
+	// Lengths counts extra inning games by number of innings played. Games
+	// scheduled for seven innings count once they go past the seventh.
+	Lengths map[int]int
+}
+
+func extraInnings(games []lineScoreGame) []ExtraInnings {
+	rows := make(map[string]*ExtraInnings)
+	for _, game := range games {
+		row, ok := rows[game.Period]
+		if !ok {
+			row = &ExtraInnings{Period: game.Period, Lengths: make(map[int]int)}
+			rows[game.Period] = row
+		}
+		row.Games++
+		innings := game.Innings()
+		if innings <= game.Game.ScheduledInnings() {
+			continue
+		}
+		row.ExtraInningGames++
+		row.Lengths[innings]++
+		if innings > row.LongestInnings {
+			row.LongestInnings = innings
+		}
+		switch game.Game.HomeResult(OnFieldResult) {
+		case Win:
+			row.HomeWins++
+		case Loss:
+			row.VisitorWins++
+		case Tie:
+			row.Ties++
+		}
+	}
+
+	var result []ExtraInnings
+	for _, period := range periods(games) {
+		result = append(result, *rows[period])
+	}
+	return result
+}
+
+// ratio is n/d, or 0 when d is 0.
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+// pct is ratio as a percentage.
+func pct(n, d int) float64 {
+	return 100 * ratio(n, d)
+}
+
+func writeJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// lineScoreFlags reads the flags shared by every lineScore subcommand and
+// loads the games.
+func lineScoreFlags(cmd *cobra.Command) ([]lineScoreGame, string, error) {
+	by, err := cmd.Flags().GetString("by")
+	if err != nil {
+		return nil, "", err
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return nil, "", err
+	}
+	if format != "text" && format != "json" {
+		return nil, "", fmt.Errorf("unknown format %q", format)
+	}
+	games, err := loadLineScoreGames(rsDataDir, by)
+	return games, format, err
+}
+
+// lineScoreCmd represents the lineScore command
+var lineScoreCmd = &cobra.Command{
+	Use:   "lineScore",
+	Short: "Inning-by-inning analyses of Retrosheet line scores",
+	Long: `Groups of analyses built on each game's line score. Games without a complete line score, or
+whose line score doesn't add up to the final score, are left out. Forfeited games count by the
+score on the field.
+
+Every subcommand takes:
+
+by: Group games by "era", "decade" or "year".
+format: "text" or "json".
+`,
+}
+
+var runsByInningCmd = &cobra.Command{
+	Use:   "runsByInning",
+	Short: "Runs per half inning and their distribution, by inning",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		games, format, err := lineScoreFlags(cmd)
+		if err != nil {
+			return err
+		}
+		rows := runsByInning(games)
+		if format == "json" {
+			return writeJSON(rows)
+		}
+		fmt.Println("Period\tInning\tHalfInnings\tRuns/HalfInning\t0\t1\t2\t3\t4\t5+")
+		for _, row := range rows {
+			inning := fmt.Sprint(row.Inning)
+			if row.Inning == 10 {
+				inning = "10+"
+			}
+			fmt.Printf("%s\t%s\t%d\t%.3f", row.Period, inning, row.HalfInnings, row.RunsPerHalfInning())
+			for _, count := range row.Distribution {
+				fmt.Printf("\t%.1f%%", pct(count, row.HalfInnings))
+			}
+			fmt.Println()
+		}
+		return nil
+	},
+}
+
+var firstInningCmd = &cobra.Command{
+	Use:   "firstInning",
+	Short: "How often teams score in the first inning, and how often that holds up",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		games, format, err := lineScoreFlags(cmd)
+		if err != nil {
+			return err
+		}
+		rows := firstInningScoring(games)
+		if format == "json" {
+			return writeJSON(rows)
+		}
+		fmt.Println("Period\tGames\tVisitorScored\tHomeScored\tEitherScored\tOnlyScorerWon")
+		for _, row := range rows {
+			fmt.Printf("%s\t%d\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f%%\n", row.Period, row.Games, pct(row.VisitorScored, row.Games), pct(row.HomeScored, row.Games), pct(row.EitherScored, row.Games), pct(row.OnlyOneScoredWon, row.OnlyOneScored))
+		}
+		return nil
+	},
+}
+
+var comebacksCmd = &cobra.Command{
+	Use:   "comebacks",
+	Short: "Comeback wins and the largest deficits overcome",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		games, format, err := lineScoreFlags(cmd)
+		if err != nil {
+			return err
+		}
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+		rows, largest := comebacks(games, top)
+		if format == "json" {
+			return writeJSON(struct {
+				Periods []Comebacks
+				Largest []ComebackGame
+			}{rows, largest})
+		}
+		fmt.Println("Period\tGames\tComebackWins\tThreeRunComebacks\tLargestDeficit")
+		for _, row := range rows {
+			fmt.Printf("%s\t%d\t%.1f%%\t%.1f%%\t%d\n", row.Period, row.Games, pct(row.ComebackWins, row.Games), pct(row.ThreeRunComebacks, row.Games), row.Largest.Deficit)
+		}
+		fmt.Println()
+		fmt.Println("Largest comebacks")
+		for _, cg := range largest {
+			fmt.Printf("%s\t%s %d @ %s %d\t%s overcame %d runs\n", cg.Date, cg.VisitingTeam, cg.VisitingScore, cg.HomeTeam, cg.HomeScore, cg.Winner, cg.Deficit)
+		}
+		return nil
+	},
+}
+
+var walkoffsCmd = &cobra.Command{
+	Use:   "walkoffs",
+	Short: "How often home teams win in their final at bat",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		games, format, err := lineScoreFlags(cmd)
+		if err != nil {
+			return err
+		}
+		rows := walkoffs(games)
+		if format == "json" {
+			return writeJSON(rows)
+		}
+		fmt.Println("Period\tGames\tWalkoffs\tOfGames\tOfHomeWins")
+		for _, row := range rows {
+			fmt.Printf("%s\t%d\t%d\t%.1f%%\t%.1f%%\n", row.Period, row.Games, row.Walkoffs, pct(row.Walkoffs, row.Games), pct(row.Walkoffs, row.HomeWins))
+		}
+		return nil
+	},
+}
+
+var extraInningsCmd = &cobra.Command{
+	Use:   "extraInnings",
+	Short: "Frequency and outcomes of extra inning games",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		games, format, err := lineScoreFlags(cmd)
+		if err != nil {
+			return err
+		}
+		rows := extraInnings(games)
+		if format == "json" {
+			return writeJSON(rows)
+		}
+		fmt.Println("Period\tGames\tExtraInnings\tHomeWinPct\tTies\tLongest")
+		for _, row := range rows {
+			fmt.Printf("%s\t%d\t%.1f%%\t%.1f%%\t%d\t%d\n", row.Period, row.Games, pct(row.ExtraInningGames, row.Games), pct(row.HomeWins, row.HomeWins+row.VisitorWins), row.Ties, row.LongestInnings)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lineScoreCmd)
+	lineScoreCmd.PersistentFlags().String("by", "era", "group games by era, decade or year")
+	lineScoreCmd.PersistentFlags().String("format", "text", "output format: text or json")
+
+	lineScoreCmd.AddCommand(runsByInningCmd)
+	lineScoreCmd.AddCommand(firstInningCmd)
+	lineScoreCmd.AddCommand(comebacksCmd)
+	lineScoreCmd.AddCommand(walkoffsCmd)
+	lineScoreCmd.AddCommand(extraInningsCmd)
+	comebacksCmd.Flags().Int("top", 10, "number of individual comebacks to list")
+}
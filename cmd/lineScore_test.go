@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testLineScoreGame(visiting, home []int) lineScoreGame {
+	return lineScoreGame{
+		Game: &RetrosheetGame{
+			Date:          time.Date(2000, time.July, 4, 0, 0, 0, 0, time.UTC),
+			VisitingTeam:  "VIS",
+			HomeTeam:      "HOM",
+			VisitingScore: sumRuns(visiting),
+			HomeScore:     sumRuns(home),
+		},
+		Period:   "2000",
+		Visiting: visiting,
+		Home:     home,
+	}
+}
+
+func TestLineScoreGames(t *testing.T) {
+	tests := []struct {
+		name            string
+		visiting        []int
+		home            []int
+		expectedDeficit int
+		expectedWalkoff bool
+		expectedInnings int
+	}{
+		{
+			name:            "home team didn't bat in the ninth",
+			visiting:        []int{0, 0, 0, 1, 0, 0, 0, 0, 0},
+			home:            []int{0, 0, 0, 0, 0, 0, 5, 2},
+			expectedDeficit: 1,
+			expectedInnings: 9,
+		},
+		{
+			name:            "walk-off in the ninth",
+			visiting:        []int{3, 0, 0, 0, 0, 0, 0, 0, 0},
+			home:            []int{0, 0, 0, 0, 0, 1, 0, 0, 3},
+			expectedDeficit: 3,
+			expectedWalkoff: true,
+			expectedInnings: 9,
+		},
+		{
+			name:            "visitors win in extras",
+			visiting:        []int{0, 0, 0, 0, 0, 0, 0, 0, 1, 2},
+			home:            []int{0, 0, 0, 0, 0, 0, 0, 1, 0, 0},
+			expectedDeficit: 1,
+			expectedInnings: 10,
+		},
+		{
+			name:            "wire to wire",
+			visiting:        []int{2, 0, 0, 0, 0, 0, 0, 0, 0},
+			home:            []int{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			expectedInnings: 9,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testLineScoreGame(test.visiting, test.home)
+			assert.Equal(t, test.expectedDeficit, largestDeficit(game))
+			assert.Equal(t, test.expectedWalkoff, isWalkoff(game))
+			assert.Equal(t, test.expectedInnings, game.Innings())
+		})
+	}
+}
+
+func TestRunsByInning(t *testing.T) {
+	games := []lineScoreGame{
+		testLineScoreGame([]int{1, 0, 0, 0, 0, 0, 0, 0, 0, 0}, []int{7, 0, 0, 0, 0, 0, 0, 0, 0, 1}),
+	}
+	rows := runsByInning(games)
+	assert.Len(t, rows, 10)
+	assert.Equal(t, InningRuns{Period: "2000", Inning: 1, HalfInnings: 2, Runs: 8, Distribution: [6]int{0, 1, 0, 0, 0, 1}}, rows[0])
+	assert.Equal(t, InningRuns{Period: "2000", Inning: 10, HalfInnings: 2, Runs: 1, Distribution: [6]int{1, 1, 0, 0, 0, 0}}, rows[9])
+}
+
+func TestPeriodLabel(t *testing.T) {
+	label, err := periodLabel("era", 1927)
+	assert.NoError(t, err)
+	assert.Equal(t, "1920 Live Ball", label)
+	label, err = periodLabel("decade", 1927)
+	assert.NoError(t, err)
+	assert.Equal(t, "1920s", label)
+	_, err = periodLabel("century", 1927)
+	assert.Error(t, err)
+}
+
+func TestExtraInningsScheduledLength(t *testing.T) {
+	nine := testLineScoreGame([]int{0, 0, 0, 0, 0, 0, 0, 1}, []int{0, 0, 0, 0, 0, 0, 0, 0})
+	nine.Visiting = append(nine.Visiting, 0)
+	nine.Home = append(nine.Home, 0)
+	nine.Game.VisitingScore, nine.Game.HomeScore = 1, 0
+
+	// An eight inning game of a 2020 doubleheader went past its seven.
+	doubleheader := testLineScoreGame([]int{0, 0, 0, 0, 0, 0, 0, 1}, []int{0, 0, 0, 0, 0, 0, 0, 0})
+	doubleheader.Game.Date = time.Date(2020, time.August, 1, 0, 0, 0, 0, time.UTC)
+	doubleheader.Game.GameNumber = 1
+
+	rows := extraInnings([]lineScoreGame{nine, doubleheader})
+	assert.Len(t, rows, 1)
+	assert.Equal(t, 2, rows[0].Games)
+	assert.Equal(t, 1, rows[0].ExtraInningGames)
+	assert.Equal(t, map[int]int{8: 1}, rows[0].Lengths)
+	assert.Equal(t, 1, rows[0].VisitorWins)
+}
@@ -0,0 +1,334 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// positionNames are the abbreviations of the scorer's position numbers.
+var positionNames = []string{"", "P", "C", "1B", "2B", "3B", "SS", "LF", "CF", "RF", "DH"}
+
+func positionName(position int) string {
+	if position < 1 || position >= len(positionNames) {
+		return "?"
+	}
+	return positionNames[position]
+}
+
+func (l Lineup) String() string {
+	batters := make([]string, len(l))
+	for i, slot := range l {
+		batters[i] = fmt.Sprintf("%s %s", slot.Player.Name, positionName(slot.Position))
+	}
+	return strings.Join(batters, ", ")
+}
+
+// LineupUsage is a batting order, with the same players at the same
+// positions, and the games a team started it.
+type LineupUsage struct {
+	Lineup Lineup
+	Games  int
+	Record SeasonRecord
+	First  time.Time
+	Last   time.Time
+}
+
+// LineupUsage returns every distinct starting lineup the team used, most used
+// first. Games with unknown lineups are left out.
+func (s Season) LineupUsage() []LineupUsage {
+	index := make(map[Lineup]*LineupUsage)
+	for _, game := range s.Games {
+		if !game.Lineup.Known() {
+			continue
+		}
+		lu, ok := index[game.Lineup]
+		if !ok {
+			lu = &LineupUsage{Lineup: game.Lineup, First: game.Date}
+			index[game.Lineup] = lu
+		}
+		lu.Games++
+		lu.Last = game.Date
+		addResult(&lu.Record, game.Result)
+	}
+	usage := make([]LineupUsage, 0, len(index))
+	for _, lu := range index {
+		usage = append(usage, *lu)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Games != usage[j].Games {
+			return usage[i].Games > usage[j].Games
+		}
+		return usage[i].First.Before(usage[j].First)
+	})
+	return usage
+}
+
+// LeadoffRecords returns the team's record with each player batting first,
+// most games first.
+func (s Season) LeadoffRecords() []PersonRecord {
+	return s.recordsBy(func(game TeamGame) Person { return game.Lineup[0].Player })
+}
+
+// PositionContinuity describes how settled a fielding position was over a
+// season: how many players started there and how often the most frequent
+// one did.
+type PositionContinuity struct {
+	Position     int
+	Games        int
+	Starters     int
+	Regular      Person
+	RegularGames int
+}
+
+func (pc PositionContinuity) RegularShare() float64 {
+	return ratio(pc.RegularGames, pc.Games)
+}
+
+// PositionContinuity returns the continuity of every position the team
+// started a player at, in scorer's order.
+func (s Season) PositionContinuity() []PositionContinuity {
+	starts := make(map[int]map[Person]int)
+	for _, game := range s.Games {
+		for _, slot := range game.Lineup {
+			if slot.Player.ID == "" {
+				continue
+			}
+			if starts[slot.Position] == nil {
+				starts[slot.Position] = make(map[Person]int)
+			}
+			starts[slot.Position][slot.Player]++
+		}
+	}
+	var continuity []PositionContinuity
+	for position, players := range starts {
+		pc := PositionContinuity{Position: position, Starters: len(players)}
+		for player, games := range players {
+			pc.Games += games
+			if games > pc.RegularGames || (games == pc.RegularGames && player.ID < pc.Regular.ID) {
+				pc.Regular = player
+				pc.RegularGames = games
+			}
+		}
+		continuity = append(continuity, pc)
+	}
+	sort.Slice(continuity, func(i, j int) bool { return continuity[i].Position < continuity[j].Position })
+	return continuity
+}
+
+// PlayerStart is a game a player was in the starting lineup.
+type PlayerStart struct {
+	Game *TeamGame
+	// BattingOrder is 1 for the leadoff hitter through 9.
+	BattingOrder int
+	Position     int
+}
+
+// playerGames holds every start of each player, in the order played.
+type playerGames map[string][]PlayerStart
+
+func newPlayerGames(btbs *ByTeamsBySeason) playerGames {
+	pg := make(playerGames)
+	for _, seasons := range btbs.BySortedSeason() {
+		for _, season := range seasons {
+			for i := range season.Games {
+				game := &season.Games[i]
+				for order, slot := range game.Lineup {
+					if slot.Player.ID == "" {
+						continue
+					}
+					pg[slot.Player.ID] = append(pg[slot.Player.ID], PlayerStart{Game: game, BattingOrder: order + 1, Position: slot.Position})
+				}
+			}
+		}
+	}
+	for _, starts := range pg {
+		sort.SliceStable(starts, func(i, j int) bool {
+			if !starts[i].Game.Date.Equal(starts[j].Game.Date) {
+				return starts[i].Game.Date.Before(starts[j].Game.Date)
+			}
+			return starts[i].Game.GameNumber < starts[j].Game.GameNumber
+		})
+	}
+	return pg
+}
+
+// PlayerSeason is a player's starts for one team in one season.
+type PlayerSeason struct {
+	Player    Person
+	Year      int
+	Team      string
+	Starts    int
+	Positions map[int]int
+	Record    SeasonRecord
+}
+
+// seasons totals a player's starts by season and team, in the order played.
+func (pg playerGames) seasons(id string) []PlayerSeason {
+	var seasons []PlayerSeason
+	for _, start := range pg[id] {
+		year := start.Game.Date.Year()
+		if len(seasons) == 0 || seasons[len(seasons)-1].Year != year || seasons[len(seasons)-1].Team != start.Game.Team {
+			seasons = append(seasons, PlayerSeason{
+				Player:    start.Game.Lineup[start.BattingOrder-1].Player,
+				Year:      year,
+				Team:      start.Game.Team,
+				Positions: make(map[int]int),
+			})
+		}
+		ps := &seasons[len(seasons)-1]
+		ps.Starts++
+		ps.Positions[start.Position]++
+		addResult(&ps.Record, start.Game.Result)
+	}
+	return seasons
+}
+
+func lineupSeason(cmd *cobra.Command) (*Season, error) {
+	franchise, err := cmd.Flags().GetString("franchise")
+	if err != nil {
+		return nil, err
+	}
+	year, err := cmd.Flags().GetInt("year")
+	if err != nil {
+		return nil, err
+	}
+	teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+	if err != nil {
+		return nil, err
+	}
+	season, ok := teamsBySeason.m[franchise][year]
+	if !ok {
+		return nil, fmt.Errorf("no games found for %s in %d", franchise, year)
+	}
+	return season, nil
+}
+
+// lineupsCmd represents the lineups command
+var lineupsCmd = &cobra.Command{
+	Use:   "lineups",
+	Short: "Starting lineup and batting order analyses",
+	Long: `Analyses of the starting lineups recorded in every game log. Games whose lineups
+Retrosheet doesn't know are left out. Players are identified by their Retrosheet ID, e.g.
+poseb001.`,
+}
+
+var lineupsMostCmd = &cobra.Command{
+	Use:   "most",
+	Short: "A team-season's most-used lineups and how many distinct lineups it used",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+		season, err := lineupSeason(cmd)
+		if err != nil {
+			return err
+		}
+		usage := season.LineupUsage()
+		fmt.Printf("%s %d used %d distinct lineups\n", season.Team, season.Year, len(usage))
+		if len(usage) > top {
+			usage = usage[:top]
+		}
+		for _, lu := range usage {
+			fmt.Printf("%d\t%s\t%s\n", lu.Games, lu.Record, lu.Lineup)
+		}
+		return nil
+	},
+}
+
+var lineupsLeadoffCmd = &cobra.Command{
+	Use:   "leadoff",
+	Short: "A team-season's record by leadoff hitter",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		season, err := lineupSeason(cmd)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Leadoff\tGames\tRecord\tPct")
+		for _, pr := range season.LeadoffRecords() {
+			name := pr.Person.Name
+			if name == "" {
+				name = "(unknown)"
+			}
+			fmt.Printf("%s\t%d\t%s\t%s\n", name, pr.Games, pr.Record, formatPct(pr.Record.Pct()))
+		}
+		return nil
+	},
+}
+
+var lineupsPositionsCmd = &cobra.Command{
+	Use:   "positions",
+	Short: "How many players started at each position and how often the regular did",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		season, err := lineupSeason(cmd)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Pos\tStarters\tRegular\tStarts\tShare")
+		for _, pc := range season.PositionContinuity() {
+			fmt.Printf("%s\t%d\t%s\t%d\t%s\n", positionName(pc.Position), pc.Starters, pc.Regular.Name, pc.RegularGames, formatPct(pc.RegularShare()))
+		}
+		return nil
+	},
+}
+
+var lineupsPlayerCmd = &cobra.Command{
+	Use:   "player",
+	Short: "A player's starts by season, team and position",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := cmd.Flags().GetString("player")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		seasons := newPlayerGames(teamsBySeason).seasons(id)
+		if len(seasons) == 0 {
+			return fmt.Errorf("no starts found for player %s", id)
+		}
+		fmt.Println(seasons[0].Player.Name)
+		for _, ps := range seasons {
+			positions := make([]int, 0, len(ps.Positions))
+			for position := range ps.Positions {
+				positions = append(positions, position)
+			}
+			sort.Slice(positions, func(i, j int) bool {
+				if ps.Positions[positions[i]] != ps.Positions[positions[j]] {
+					return ps.Positions[positions[i]] > ps.Positions[positions[j]]
+				}
+				return positions[i] < positions[j]
+			})
+			var played []string
+			for _, position := range positions {
+				played = append(played, fmt.Sprintf("%s %d", positionName(position), ps.Positions[position]))
+			}
+			fmt.Printf("%d\t%s\t%d\t%s\t%s\n", ps.Year, ps.Team, ps.Starts, ps.Record, strings.Join(played, ", "))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lineupsCmd)
+	lineupsCmd.AddCommand(lineupsMostCmd, lineupsLeadoffCmd, lineupsPositionsCmd, lineupsPlayerCmd)
+
+	for _, cmd := range []*cobra.Command{lineupsMostCmd, lineupsLeadoffCmd, lineupsPositionsCmd} {
+		cmd.Flags().String("franchise", "", "franchise to report on")
+		cmd.Flags().Int("year", 0, "season to report on")
+		cmd.MarkFlagRequired("franchise")
+		cmd.MarkFlagRequired("year")
+	}
+	lineupsMostCmd.Flags().Int("top", 10, "number of lineups to list")
+
+	lineupsPlayerCmd.Flags().String("player", "", "player's Retrosheet ID")
+	lineupsPlayerCmd.MarkFlagRequired("player")
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineupAnalyses(t *testing.T) {
+	regular := coleg001Lineup
+	platoon := regular
+	platoon[0] = LineupSlot{Player: Person{ID: "gardb001", Name: "Brett Gardner"}, Position: 8}
+	season := testSeason("NYA", 2019, "AL", Win, Win, Loss, Win)
+	for i := range season.Games {
+		season.Games[i].Lineup = regular
+	}
+	season.Games[2].Lineup = platoon
+	season.Games[3].Lineup = Lineup{}
+
+	usage := season.LineupUsage()
+	require.Len(t, usage, 2)
+	assert.Equal(t, regular, usage[0].Lineup)
+	assert.Equal(t, 2, usage[0].Games)
+	assert.Equal(t, SeasonRecord{Wins: 2}, usage[0].Record)
+	assert.Equal(t, SeasonRecord{Losses: 1}, usage[1].Record)
+
+	assert.Equal(t, []PersonRecord{
+		{Person: regular[0].Player, Games: 2, Record: SeasonRecord{Wins: 2}},
+		{Person: platoon[0].Player, Games: 1, Record: SeasonRecord{Losses: 1}},
+	}, season.LeadoffRecords())
+
+	continuity := season.PositionContinuity()
+	require.Len(t, continuity, 9)
+	assert.Equal(t, 2, continuity[0].Position)
+	cf := continuity[6]
+	assert.Equal(t, PositionContinuity{Position: 8, Games: 3, Starters: 2, Regular: regular[0].Player, RegularGames: 2}, cf)
+	assert.InDelta(t, 2.0/3, cf.RegularShare(), 1e-9)
+}
+
+func TestPlayerGames(t *testing.T) {
+	teamsBySeason, err := GetTeamsBySeason("./test_data")
+	require.NoError(t, err)
+	pg := newPlayerGames(teamsBySeason)
+
+	starts := pg["yastm001"]
+	require.Len(t, starts, 2)
+	assert.Equal(t, 1, starts[0].BattingOrder)
+	assert.Equal(t, 8, starts[0].Position)
+	assert.Equal(t, 2000, starts[0].Game.Date.Year())
+
+	assert.Equal(t, []PlayerSeason{
+		{Player: Person{ID: "yastm001", Name: "Mike Yastrzemski"}, Year: 2000, Team: "SFN", Starts: 1, Positions: map[int]int{8: 1}, Record: SeasonRecord{Losses: 1}},
+		{Player: Person{ID: "yastm001", Name: "Mike Yastrzemski"}, Year: 2001, Team: "SFN", Starts: 1, Positions: map[int]int{8: 1}, Record: SeasonRecord{Losses: 1}},
+	}, pg.seasons("yastm001"))
+	assert.Empty(t, pg.seasons("nobody"))
+}
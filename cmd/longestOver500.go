@@ -6,6 +6,7 @@ package cmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -85,7 +86,7 @@ Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		teamsBySeason, err := GetTeamsBySeason("cmd/rs_data")
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
 		if err != nil {
 			return err
 		}
@@ -107,6 +108,19 @@ to quickly create a Cobra application.`,
 		sort.Slice(allStreaks, func(i, j int) bool {
 			return allStreaks[i].games < allStreaks[j].games
 		})
+		htmlPath, err := cmd.Flags().GetString("html")
+		if err != nil {
+			return err
+		}
+		if htmlPath != "" {
+			report := Report{
+				Title:    "Longest stretches at or above .500",
+				Sections: []ReportSection{streaksReportSection(allStreaks)},
+			}
+			if err := writeReportFile(htmlPath, report); err != nil {
+				return err
+			}
+		}
 		for _, streak := range allStreaks {
 			fmt.Printf("Franchise: %s, Games: %d, Wins: %d, Losses: %d, start: %s, startGame: %d, end: %s, endGame: %d\n", streak.franchise, streak.games, streak.wins, streak.losses, streak.start.String(), streak.startGame, streak.end.String(), streak.endGame)
 		}
@@ -114,8 +128,29 @@ to quickly create a Cobra application.`,
 	},
 }
 
+// streaksReportSection tabulates each franchise's longest streak, longest
+// first.
+func streaksReportSection(streaks []teamStreak) ReportSection {
+	table := &ReportTable{Columns: []string{"Franchise", "Games", "Wins", "Losses", "Start", "Start game", "End", "End game"}}
+	for i := len(streaks) - 1; i >= 0; i-- {
+		streak := streaks[i]
+		table.Rows = append(table.Rows, []string{
+			streak.franchise,
+			strconv.Itoa(streak.games),
+			strconv.Itoa(streak.wins),
+			strconv.Itoa(streak.losses),
+			streak.start.Format("2006-01-02"),
+			strconv.Itoa(streak.startGame),
+			streak.end.Format("2006-01-02"),
+			strconv.Itoa(streak.endGame),
+		})
+	}
+	return ReportSection{Heading: "Streaks by franchise", Table: table}
+}
+
 func init() {
 	rootCmd.AddCommand(longestOver500Cmd)
+	longestOver500Cmd.Flags().String("html", "", "also write the streaks to this HTML report file")
 
 	// Here you will define your flags and configuration settings.
 
@@ -0,0 +1,388 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// ManagerStint is a run of consecutive games one manager led a team within a
+// season. A manager who steps away for a game and returns has two stints.
+type ManagerStint struct {
+	Manager   Person
+	Team      string
+	Franchise string
+	Year      int
+	FirstGame int
+	LastGame  int
+	Start     time.Time
+	End       time.Time
+	Record    SeasonRecord
+}
+
+func addResult(sr *SeasonRecord, result Result) {
+	switch result {
+	case Win:
+		sr.Wins++
+	case Loss:
+		sr.Losses++
+	case Tie:
+		sr.Ties++
+	}
+}
+
+// ManagerStints splits the season into the stints of each manager in the
+// order they managed.
+func (s Season) ManagerStints() []ManagerStint {
+	var stints []ManagerStint
+	for _, game := range s.Games {
+		if len(stints) == 0 || stints[len(stints)-1].Manager.ID != game.Manager.ID {
+			stints = append(stints, ManagerStint{
+				Manager:   game.Manager,
+				Team:      s.Team,
+				Franchise: s.Franchise,
+				Year:      s.Year,
+				FirstGame: game.TeamGameNumber,
+				Start:     game.Date,
+			})
+		}
+		stint := &stints[len(stints)-1]
+		stint.LastGame = game.TeamGameNumber
+		stint.End = game.Date
+		addResult(&stint.Record, game.Result)
+	}
+	return stints
+}
+
+// ManagerChange is a mid-season change of manager, with the team's record
+// before and after it.
+type ManagerChange struct {
+	Franchise  string
+	Year       int
+	Date       time.Time
+	GameNumber int
+	From       Person
+	To         Person
+	Before     SeasonRecord
+	After      SeasonRecord
+}
+
+// fillInGames is the most games a stand-in may manage, between two stints
+// of the same manager, and still count as filling in rather than as two
+// changes of manager. It covers ejections, suspensions and short absences.
+const fillInGames = 3
+
+// tenures merges each fill-in stint, along with the return of the manager
+// it stood in for, into that manager's stint before it.
+func tenures(stints []ManagerStint) []ManagerStint {
+	var merged []ManagerStint
+	for i := 0; i < len(stints); i++ {
+		stint := stints[i]
+		if len(merged) > 0 && i+1 < len(stints) &&
+			stint.LastGame-stint.FirstGame+1 <= fillInGames &&
+			merged[len(merged)-1].Manager.ID == stints[i+1].Manager.ID {
+			last := &merged[len(merged)-1]
+			for _, part := range stints[i : i+2] {
+				last.LastGame = part.LastGame
+				last.End = part.End
+				last.Record.Wins += part.Record.Wins
+				last.Record.Losses += part.Record.Losses
+				last.Record.Ties += part.Record.Ties
+			}
+			i++
+			continue
+		}
+		merged = append(merged, stint)
+	}
+	return merged
+}
+
+// ManagerChanges lists every change of manager during the season. A stand-in
+// who manages at most fillInGames games before the previous manager returns
+// is not a change.
+func (s Season) ManagerChanges() []ManagerChange {
+	stints := tenures(s.ManagerStints())
+	overall := s.GetSeasonRecord()
+	var changes []ManagerChange
+	var before SeasonRecord
+	for i := 1; i < len(stints); i++ {
+		before.Wins += stints[i-1].Record.Wins
+		before.Losses += stints[i-1].Record.Losses
+		before.Ties += stints[i-1].Record.Ties
+		changes = append(changes, ManagerChange{
+			Franchise:  s.Franchise,
+			Year:       s.Year,
+			Date:       stints[i].Start,
+			GameNumber: stints[i].FirstGame,
+			From:       stints[i-1].Manager,
+			To:         stints[i].Manager,
+			Before:     before,
+			After: SeasonRecord{
+				Wins:   overall.Wins - before.Wins,
+				Losses: overall.Losses - before.Losses,
+				Ties:   overall.Ties - before.Ties,
+			},
+		})
+	}
+	return changes
+}
+
+// ManagerCareer is a manager's record overall, in one-run games and with
+// each franchise.
+type ManagerCareer struct {
+	Manager Person
+	Record  SeasonRecord
+	OneRun  SeasonRecord
+	// ByTeam is the record with each franchise, in the order first managed.
+	ByTeam []ManagerTeamRecord
+	// Stints are the manager's tenures, with any fill-in's games merged in.
+	Stints []ManagerStint
+}
+
+// ManagerTeamRecord is a manager's record with one franchise.
+type ManagerTeamRecord struct {
+	Franchise string
+	Record    SeasonRecord
+}
+
+// managerGames holds every game each manager led, in the order played.
+type managerGames map[string][]TeamGame
+
+func newManagerGames(btbs *ByTeamsBySeason) managerGames {
+	mg := make(managerGames)
+	for _, seasons := range btbs.BySortedSeason() {
+		for _, season := range seasons {
+			for _, game := range season.Games {
+				if game.Manager.ID == "" {
+					continue
+				}
+				mg[game.Manager.ID] = append(mg[game.Manager.ID], game)
+			}
+		}
+	}
+	for _, games := range mg {
+		sort.SliceStable(games, func(i, j int) bool {
+			if !games[i].Date.Equal(games[j].Date) {
+				return games[i].Date.Before(games[j].Date)
+			}
+			return games[i].GameNumber < games[j].GameNumber
+		})
+	}
+	return mg
+}
+
+// tally totals a manager's overall and one-run records.
+func tally(games []TeamGame) ManagerCareer {
+	career := ManagerCareer{Manager: games[0].Manager}
+	for _, game := range games {
+		addResult(&career.Record, game.Result)
+		if game.TeamScore-game.OpponentScore == 1 || game.OpponentScore-game.TeamScore == 1 {
+			addResult(&career.OneRun, game.Result)
+		}
+	}
+	return career
+}
+
+func (mg managerGames) career(id string, btbs *ByTeamsBySeason) (ManagerCareer, bool) {
+	games, ok := mg[id]
+	if !ok {
+		return ManagerCareer{}, false
+	}
+	career := tally(games)
+	teams := make(map[string]int)
+	for _, game := range games {
+		i, ok := teams[game.Franchise]
+		if !ok {
+			i = len(career.ByTeam)
+			teams[game.Franchise] = i
+			career.ByTeam = append(career.ByTeam, ManagerTeamRecord{Franchise: game.Franchise})
+		}
+		addResult(&career.ByTeam[i].Record, game.Result)
+	}
+
+	seasons := make(map[*Season]struct{})
+	for _, game := range games {
+		if season, ok := btbs.m[game.Franchise][game.Date.Year()]; ok {
+			seasons[season] = struct{}{}
+		}
+	}
+	for season := range seasons {
+		for _, stint := range tenures(season.ManagerStints()) {
+			if stint.Manager.ID == id {
+				career.Stints = append(career.Stints, stint)
+			}
+		}
+	}
+	sort.Slice(career.Stints, func(i, j int) bool { return career.Stints[i].Start.Before(career.Stints[j].Start) })
+	return career, true
+}
+
+type ManagerStreak struct {
+	Manager Person
+	WinStreak
+}
+
+// longestStreaks returns each manager's longest run of consecutive wins,
+// longest first. A tie ends a streak.
+func (mg managerGames) longestStreaks(top int) []ManagerStreak {
+	var streaks []ManagerStreak
+	for _, games := range mg {
+		best := longestWinStreak(len(games), func(i int) (Result, time.Time) { return games[i].Result, games[i].Date })
+		if best.Wins > 0 {
+			streaks = append(streaks, ManagerStreak{Manager: games[0].Manager, WinStreak: best})
+		}
+	}
+	return streaks[:rankStreaks(streaks, len(streaks), top, func(i int) WinStreak { return streaks[i].WinStreak })]
+}
+
+// oneRunRecords returns the one-run records of managers with at least
+// minGames decided one-run games, best first.
+func (mg managerGames) oneRunRecords(minGames, top int) []ManagerCareer {
+	var careers []ManagerCareer
+	for _, games := range mg {
+		career := tally(games)
+		if career.OneRun.Wins+career.OneRun.Losses >= minGames {
+			careers = append(careers, career)
+		}
+	}
+	sort.Slice(careers, func(i, j int) bool {
+		if careers[i].OneRun.Pct() != careers[j].OneRun.Pct() {
+			return careers[i].OneRun.Pct() > careers[j].OneRun.Pct()
+		}
+		return careers[i].Manager.ID < careers[j].Manager.ID
+	})
+	if len(careers) > top {
+		careers = careers[:top]
+	}
+	return careers
+}
+
+// managersCmd represents the managers command
+var managersCmd = &cobra.Command{
+	Use:   "managers",
+	Short: "Manager records from the game logs",
+	Long: `Analyses of the managers named in every game log. Managers are identified by their
+Retrosheet ID, e.g. bochb002.`,
+}
+
+var managerRecordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "A manager's career, one-run and per-team records",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := cmd.Flags().GetString("manager")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		career, ok := newManagerGames(teamsBySeason).career(id, teamsBySeason)
+		if !ok {
+			return fmt.Errorf("no games found for manager %s", id)
+		}
+		fmt.Printf("%s: %s (%s)\n", career.Manager.Name, career.Record, formatPct(career.Record.Pct()))
+		fmt.Printf("One-run games: %s (%s)\n", career.OneRun, formatPct(career.OneRun.Pct()))
+		for _, team := range career.ByTeam {
+			fmt.Printf("%s\t%s (%s)\n", team.Franchise, team.Record, formatPct(team.Record.Pct()))
+		}
+		for _, stint := range career.Stints {
+			fmt.Printf("%d\t%s\tgames %d-%d\t%s\n", stint.Year, stint.Team, stint.FirstGame, stint.LastGame, stint.Record)
+		}
+		return nil
+	},
+}
+
+var managerStreaksCmd = &cobra.Command{
+	Use:   "streaks",
+	Short: "Longest winning streaks by a manager",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		for _, streak := range newManagerGames(teamsBySeason).longestStreaks(top) {
+			fmt.Printf("%s\t%d\t%s to %s\n", streak.Manager.Name, streak.Wins, streak.Start.Format("2006-01-02"), streak.End.Format("2006-01-02"))
+		}
+		return nil
+	},
+}
+
+var managerOneRunCmd = &cobra.Command{
+	Use:   "oneRun",
+	Short: "Best career records in one-run games",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		minGames, err := cmd.Flags().GetInt("min-games")
+		if err != nil {
+			return err
+		}
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		for _, career := range newManagerGames(teamsBySeason).oneRunRecords(minGames, top) {
+			fmt.Printf("%s\t%s\t%s\toverall %s\n", career.Manager.Name, career.OneRun, formatPct(career.OneRun.Pct()), formatPct(career.Record.Pct()))
+		}
+		return nil
+	},
+}
+
+var managerChangesCmd = &cobra.Command{
+	Use:   "changes",
+	Short: "Mid-season manager changes with the team's record before and after",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+		franchise, err := cmd.Flags().GetString("franchise")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		for _, season := range teamsBySeason.SeasonsInYear(year) {
+			if franchise != "" && season.Franchise != franchise {
+				continue
+			}
+			for _, change := range season.ManagerChanges() {
+				fmt.Printf("%s\t%s\tgame %d\t%s -> %s\tbefore %s (%s)\tafter %s (%s)\n",
+					change.Franchise, change.Date.Format("2006-01-02"), change.GameNumber, change.From.Name, change.To.Name,
+					change.Before, formatPct(change.Before.Pct()), change.After, formatPct(change.After.Pct()))
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(managersCmd)
+	managersCmd.AddCommand(managerRecordCmd, managerStreaksCmd, managerOneRunCmd, managerChangesCmd)
+
+	managerRecordCmd.Flags().String("manager", "", "manager's Retrosheet ID")
+	managerRecordCmd.MarkFlagRequired("manager")
+
+	managerStreaksCmd.Flags().Int("top", 10, "number of streaks to list")
+
+	managerOneRunCmd.Flags().Int("min-games", 300, "fewest decided one-run games for a manager to be listed")
+	managerOneRunCmd.Flags().Int("top", 10, "number of managers to list")
+
+	managerChangesCmd.Flags().Int("year", 0, "season to check")
+	managerChangesCmd.Flags().String("franchise", "", "only show this franchise")
+	managerChangesCmd.MarkFlagRequired("year")
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerChanges(t *testing.T) {
+	fired := Person{ID: "oldm001", Name: "Old Manager"}
+	interim := Person{ID: "newm001", Name: "New Manager"}
+	season := testSeason("PHI", 2022, "NL", Loss, Loss, Win, Loss, Win, Win, Win, Tie)
+	for i := range season.Games {
+		season.Games[i].Manager = fired
+		if i >= 4 {
+			season.Games[i].Manager = interim
+		}
+	}
+
+	stints := season.ManagerStints()
+	require.Len(t, stints, 2)
+	assert.Equal(t, 1, stints[0].FirstGame)
+	assert.Equal(t, 4, stints[0].LastGame)
+	assert.Equal(t, SeasonRecord{Wins: 1, Losses: 3}, stints[0].Record)
+
+	assert.Equal(t, []ManagerChange{{
+		Franchise:  "PHI",
+		Year:       2022,
+		Date:       time.Date(2022, time.April, 5, 0, 0, 0, 0, time.UTC),
+		GameNumber: 5,
+		From:       fired,
+		To:         interim,
+		Before:     SeasonRecord{Wins: 1, Losses: 3},
+		After:      SeasonRecord{Wins: 3, Ties: 1},
+	}}, season.ManagerChanges())
+}
+
+func TestManagerGames(t *testing.T) {
+	teamsBySeason, err := GetTeamsBySeason("./test_data")
+	require.NoError(t, err)
+	mg := newManagerGames(teamsBySeason)
+
+	career, ok := mg.career("rossd001", teamsBySeason)
+	require.True(t, ok)
+	assert.Equal(t, "David Ross", career.Manager.Name)
+	assert.Equal(t, SeasonRecord{Wins: 2}, career.Record)
+	assert.Equal(t, SeasonRecord{}, career.OneRun)
+	require.Len(t, career.Stints, 2)
+	assert.Equal(t, 2000, career.Stints[0].Year)
+
+	_, ok = mg.career("nobody", teamsBySeason)
+	assert.False(t, ok)
+
+	streaks := mg.longestStreaks(1)
+	require.Len(t, streaks, 1)
+	assert.Equal(t, 2, streaks[0].Wins)
+	// None of the test games were decided by one run.
+	assert.Empty(t, mg.oneRunRecords(1, 10))
+}
+
+func TestManagerCareerTwoFranchises(t *testing.T) {
+	skipper := Person{ID: "skip001", Name: "Skipper"}
+	coach := Person{ID: "coac001", Name: "Bench Coach"}
+	first := testSeason("NYA", 2001, "AL", Win, Loss, Win, Win)
+	second := testSeason("BOS", 2003, "AL", Loss, Win, Loss)
+	for _, season := range []*Season{first, second} {
+		for i := range season.Games {
+			season.Games[i].Manager = skipper
+		}
+	}
+	// The coach filled in for one game.
+	first.Games[1].Manager = coach
+	btbs := &ByTeamsBySeason{m: map[string]map[int]*Season{
+		"NYA": {2001: first},
+		"BOS": {2003: second},
+	}}
+
+	career, ok := newManagerGames(btbs).career("skip001", btbs)
+	require.True(t, ok)
+	assert.Equal(t, SeasonRecord{Wins: 4, Losses: 2}, career.Record)
+	assert.Equal(t, []ManagerTeamRecord{
+		{Franchise: "NYA", Record: SeasonRecord{Wins: 3}},
+		{Franchise: "BOS", Record: SeasonRecord{Wins: 1, Losses: 2}},
+	}, career.ByTeam)
+	require.Len(t, career.Stints, 2)
+	assert.Equal(t, "NYA", career.Stints[0].Franchise)
+	assert.Equal(t, 1, career.Stints[0].FirstGame)
+	assert.Equal(t, 4, career.Stints[0].LastGame)
+	assert.Equal(t, "BOS", career.Stints[1].Franchise)
+}
+
+func TestTally(t *testing.T) {
+	manager := Person{ID: "mgr001", Name: "Manager"}
+	games := []TeamGame{
+		{Manager: manager, TeamScore: 3, OpponentScore: 2, Result: Win},
+		{Manager: manager, TeamScore: 4, OpponentScore: 5, Result: Loss},
+		{Manager: manager, TeamScore: 2, OpponentScore: 3, Result: Loss},
+		{Manager: manager, TeamScore: 9, OpponentScore: 1, Result: Win},
+	}
+	career := tally(games)
+	assert.Equal(t, manager, career.Manager)
+	assert.Equal(t, SeasonRecord{Wins: 2, Losses: 2}, career.Record)
+	assert.Equal(t, SeasonRecord{Wins: 1, Losses: 2}, career.OneRun)
+}
+
+func TestManagerChangesFillIn(t *testing.T) {
+	regular := Person{ID: "regm001", Name: "Regular Manager"}
+	bench := Person{ID: "benc001", Name: "Bench Coach"}
+	successor := Person{ID: "nexm001", Name: "Next Manager"}
+	tests := []struct {
+		name     string
+		managers []Person
+		changes  int
+	}{
+		{"one game ejection", []Person{regular, bench, regular, regular, regular, regular}, 0},
+		{"three game suspension", []Person{regular, bench, bench, bench, regular, regular}, 0},
+		{"four games is a change", []Person{regular, bench, bench, bench, bench, regular}, 2},
+		{"fill-in then firing", []Person{regular, bench, regular, regular, successor, successor}, 1},
+		{"short stint at season end", []Person{regular, regular, regular, regular, regular, bench}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			season := testSeason("PHI", 2022, "NL", Win, Loss, Win, Loss, Win, Loss)
+			for i := range season.Games {
+				season.Games[i].Manager = tt.managers[i]
+			}
+			changes := season.ManagerChanges()
+			assert.Len(t, changes, tt.changes)
+			if tt.name == "fill-in then firing" {
+				assert.Equal(t, regular, changes[0].From)
+				assert.Equal(t, 5, changes[0].GameNumber)
+				assert.Equal(t, SeasonRecord{Wins: 2, Losses: 2}, changes[0].Before)
+				assert.Equal(t, SeasonRecord{Wins: 1, Losses: 1}, changes[0].After)
+			}
+		})
+	}
+}
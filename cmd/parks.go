@@ -0,0 +1,420 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// Park is a row of Retrosheet's park code file.
+type Park struct {
+	ID    string
+	Name  string
+	City  string
+	State string
+}
+
+// getParks reads a park metadata CSV in Retrosheet's parkcode.txt layout:
+// PARKID,NAME,AKA,CITY,STATE,START,END,LEAGUE,NOTES. A header row is skipped.
+func getParks(path string) (map[string]Park, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	csvReader := csv.NewReader(f)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	parks := make(map[string]Park)
+	for i, record := range records {
+		if i == 0 && record[0] == "PARKID" {
+			continue
+		}
+		if len(record) < 5 {
+			return nil, fmt.Errorf("%s line %d: expected at least 5 fields", path, i+1)
+		}
+		parks[record[0]] = Park{ID: record[0], Name: record[1], City: record[3], State: record[4]}
+	}
+	return parks, nil
+}
+
+// ParkFactor compares scoring at a park with scoring in its home teams' road
+// games over the same seasons. 100 is neutral; above 100 favors hitters.
+type ParkFactor struct {
+	Park        Park
+	FirstYear   int
+	LastYear    int
+	Games       int
+	Runs        int
+	HomeWins    int
+	Decided     int
+	RoadRunsPer float64
+}
+
+func (pf ParkFactor) RunsPerGame() float64 {
+	if pf.Games == 0 {
+		return 0
+	}
+	return float64(pf.Runs) / float64(pf.Games)
+}
+
+func (pf ParkFactor) HomeWinPct() float64 {
+	if pf.Decided == 0 {
+		return 0
+	}
+	return float64(pf.HomeWins) / float64(pf.Decided)
+}
+
+func (pf ParkFactor) Factor() float64 {
+	if pf.RoadRunsPer == 0 {
+		return 0
+	}
+	return pf.RunsPerGame() / pf.RoadRunsPer * 100
+}
+
+// parkFactors computes the factor of every park with at least minGames games
+// between since and until, inclusive. Each home team's road scoring is
+// weighted by the games it played at the park that season. Parks missing from
+// the metadata are named by their ID.
+func parkFactors(games []*RetrosheetGame, parks map[string]Park, since, until, minGames int) []ParkFactor {
+	type teamYear struct {
+		team string
+		year int
+	}
+	type roadTotals struct{ games, runs int }
+	road := make(map[teamYear]*roadTotals)
+	homeGames := make(map[string]map[teamYear]int)
+	factors := make(map[string]*ParkFactor)
+
+	for _, game := range games {
+		year := game.Date.Year()
+		if year < since || year > until {
+			continue
+		}
+		runs := game.VisitingScore + game.HomeScore
+
+		visitor := teamYear{team: game.VisitingTeam, year: year}
+		if road[visitor] == nil {
+			road[visitor] = &roadTotals{}
+		}
+		road[visitor].games++
+		road[visitor].runs += runs
+
+		pf, ok := factors[game.ParkID]
+		if !ok {
+			park, ok := parks[game.ParkID]
+			if !ok {
+				park = Park{ID: game.ParkID, Name: game.ParkID}
+			}
+			pf = &ParkFactor{Park: park, FirstYear: year}
+			factors[game.ParkID] = pf
+			homeGames[game.ParkID] = make(map[teamYear]int)
+		}
+		pf.LastYear = year
+		pf.Games++
+		pf.Runs += runs
+		switch game.HomeResult(resultMode) {
+		case Win:
+			pf.HomeWins++
+			pf.Decided++
+		case Loss:
+			pf.Decided++
+		}
+		homeGames[game.ParkID][teamYear{team: game.HomeTeam, year: year}]++
+	}
+
+	var result []ParkFactor
+	for id, pf := range factors {
+		if pf.Games < minGames {
+			continue
+		}
+		var weighted float64
+		var weight int
+		for ty, n := range homeGames[id] {
+			totals, ok := road[ty]
+			if !ok || totals.games == 0 {
+				continue
+			}
+			weighted += float64(n) * float64(totals.runs) / float64(totals.games)
+			weight += n
+		}
+		if weight > 0 {
+			pf.RoadRunsPer = weighted / float64(weight)
+		}
+		result = append(result, *pf)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Factor() != result[j].Factor() {
+			return result[i].Factor() > result[j].Factor()
+		}
+		return result[i].Park.ID < result[j].Park.ID
+	})
+	return result
+}
+
+type TeamAttendance struct {
+	Year      int
+	Team      string
+	Franchise string
+	// Games counts the home games with known attendance.
+	Games int
+	Total int
+}
+
+func (ta TeamAttendance) Average() float64 {
+	if ta.Games == 0 {
+		return 0
+	}
+	return float64(ta.Total) / float64(ta.Games)
+}
+
+// attendanceBySeason totals each team's home attendance by season, ordered by
+// year and then by average attendance, highest first.
+func attendanceBySeason(games []*RetrosheetGame, franchiseConverter FranchiseConverter) []TeamAttendance {
+	index := make(map[string]*TeamAttendance)
+	for _, game := range games {
+		if game.Attendance == 0 {
+			continue
+		}
+		key := fmt.Sprintf("%d%s", game.Date.Year(), game.HomeTeam)
+		ta, ok := index[key]
+		if !ok {
+			ta = &TeamAttendance{Year: game.Date.Year(), Team: game.HomeTeam, Franchise: franchiseConverter.Convert(game.HomeTeam)}
+			index[key] = ta
+		}
+		ta.Games++
+		ta.Total += game.Attendance
+	}
+	rows := make([]TeamAttendance, 0, len(index))
+	for _, ta := range index {
+		rows = append(rows, *ta)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Year != rows[j].Year {
+			return rows[i].Year < rows[j].Year
+		}
+		if rows[i].Average() != rows[j].Average() {
+			return rows[i].Average() > rows[j].Average()
+		}
+		return rows[i].Team < rows[j].Team
+	})
+	return rows
+}
+
+type GameLength struct {
+	Year int
+	// Games and Minutes cover every game of known duration; NineInning*
+	// only those that went exactly nine innings.
+	Games             int
+	Minutes           int
+	NineInningGames   int
+	NineInningMinutes int
+	LongestMinutes    int
+}
+
+func (gl GameLength) AverageMinutes() float64 {
+	if gl.Games == 0 {
+		return 0
+	}
+	return float64(gl.Minutes) / float64(gl.Games)
+}
+
+func (gl GameLength) AverageNineInningMinutes() float64 {
+	if gl.NineInningGames == 0 {
+		return 0
+	}
+	return float64(gl.NineInningMinutes) / float64(gl.NineInningGames)
+}
+
+// regulationOuts is the most outs a nine inning game can last.
+const regulationOuts = 54
+
+// gameLengths averages game duration by year. A nine inning game lasts 51
+// outs when the home team doesn't bat in the ninth, and up to 54.
+func gameLengths(games []*RetrosheetGame) []GameLength {
+	var rows []GameLength
+	index := make(map[int]int)
+	for _, game := range games {
+		if game.DurationMinutes == 0 {
+			continue
+		}
+		year := game.Date.Year()
+		i, ok := index[year]
+		if !ok {
+			i = len(rows)
+			index[year] = i
+			rows = append(rows, GameLength{Year: year})
+		}
+		row := &rows[i]
+		row.Games++
+		row.Minutes += game.DurationMinutes
+		if game.LengthInOuts >= 51 && game.LengthInOuts <= regulationOuts {
+			row.NineInningGames++
+			row.NineInningMinutes += game.DurationMinutes
+		}
+		if game.DurationMinutes > row.LongestMinutes {
+			row.LongestMinutes = game.DurationMinutes
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Year < rows[j].Year })
+	return rows
+}
+
+func parksFormat(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return "", err
+	}
+	if format != "text" && format != "json" {
+		return "", fmt.Errorf("unknown format %q", format)
+	}
+	return format, nil
+}
+
+// parksCmd represents the parks command
+var parksCmd = &cobra.Command{
+	Use:   "parks",
+	Short: "Ballpark analytics from park IDs, attendance and game times",
+	Long: `Analyses built on the park, attendance and game duration recorded in each game log.
+Games missing a value are left out of the analyses that need it.
+
+Every subcommand takes:
+
+format: "text" or "json".
+`,
+}
+
+var parkFactorsCmd = &cobra.Command{
+	Use:   "factors",
+	Short: "Runs per game, home win rate and park factor by park",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := parksFormat(cmd)
+		if err != nil {
+			return err
+		}
+		since, err := cmd.Flags().GetInt("since")
+		if err != nil {
+			return err
+		}
+		until, err := cmd.Flags().GetInt("until")
+		if err != nil {
+			return err
+		}
+		minGames, err := cmd.Flags().GetInt("min-games")
+		if err != nil {
+			return err
+		}
+		parkFile, err := cmd.Flags().GetString("park-file")
+		if err != nil {
+			return err
+		}
+		parks := make(map[string]Park)
+		if parkFile != "" {
+			if parks, err = getParks(parkFile); err != nil {
+				return err
+			}
+		}
+		games, err := sortedRetrosheetGames(rsDataDir)
+		if err != nil {
+			return err
+		}
+		factors := parkFactors(games, parks, since, until, minGames)
+		if format == "json" {
+			return writeJSON(factors)
+		}
+		fmt.Println("Park\tName\tCity\tYears\tGames\tRuns/Game\tHomeWinPct\tParkFactor")
+		for _, pf := range factors {
+			fmt.Printf("%s\t%s\t%s\t%d-%d\t%d\t%.2f\t%s\t%.0f\n", pf.Park.ID, pf.Park.Name, pf.Park.City, pf.FirstYear, pf.LastYear, pf.Games, pf.RunsPerGame(), formatPct(pf.HomeWinPct()), pf.Factor())
+		}
+		return nil
+	},
+}
+
+var parkAttendanceCmd = &cobra.Command{
+	Use:   "attendance",
+	Short: "Average home attendance by team and season",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := parksFormat(cmd)
+		if err != nil {
+			return err
+		}
+		franchise, err := cmd.Flags().GetString("franchise")
+		if err != nil {
+			return err
+		}
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+		franchiseConverter, err := getFranchiseConverter(filepath.Join(rsDataDir, "misc/CurrentNames.csv"))
+		if err != nil {
+			return err
+		}
+		games, err := sortedRetrosheetGames(rsDataDir)
+		if err != nil {
+			return err
+		}
+		var rows []TeamAttendance
+		for _, row := range attendanceBySeason(games, franchiseConverter) {
+			if (franchise == "" || row.Franchise == franchise) && (year == 0 || row.Year == year) {
+				rows = append(rows, row)
+			}
+		}
+		if format == "json" {
+			return writeJSON(rows)
+		}
+		fmt.Println("Year\tTeam\tGames\tTotal\tAverage")
+		for _, row := range rows {
+			fmt.Printf("%d\t%s\t%d\t%d\t%.0f\n", row.Year, row.Team, row.Games, row.Total, row.Average())
+		}
+		return nil
+	},
+}
+
+var parkGameLengthCmd = &cobra.Command{
+	Use:   "gameLength",
+	Short: "Average game duration by year",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := parksFormat(cmd)
+		if err != nil {
+			return err
+		}
+		games, err := sortedRetrosheetGames(rsDataDir)
+		if err != nil {
+			return err
+		}
+		rows := gameLengths(games)
+		if format == "json" {
+			return writeJSON(rows)
+		}
+		fmt.Println("Year\tGames\tAvgMinutes\tAvgNineInningMinutes\tLongest")
+		for _, row := range rows {
+			fmt.Printf("%d\t%d\t%.0f\t%.0f\t%d\n", row.Year, row.Games, row.AverageMinutes(), row.AverageNineInningMinutes(), row.LongestMinutes)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(parksCmd)
+	parksCmd.AddCommand(parkFactorsCmd, parkAttendanceCmd, parkGameLengthCmd)
+	parksCmd.PersistentFlags().String("format", "text", "output format: text or json")
+
+	parkFactorsCmd.Flags().Int("since", 0, "first season to include")
+	parkFactorsCmd.Flags().Int("until", 9999, "last season to include")
+	parkFactorsCmd.Flags().Int("min-games", 200, "fewest games for a park to be listed")
+	parkFactorsCmd.Flags().String("park-file", "", "park metadata CSV in Retrosheet's parkcode.txt layout")
+
+	parkAttendanceCmd.Flags().String("franchise", "", "only show this franchise")
+	parkAttendanceCmd.Flags().Int("year", 0, "only show this season")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testParkGames() []*RetrosheetGame {
+	game := func(year int, park, visitor, home string, visitingScore, homeScore, attendance, minutes, outs int) *RetrosheetGame {
+		return &RetrosheetGame{
+			Date:            time.Date(year, time.June, 1, 0, 0, 0, 0, time.UTC),
+			ParkID:          park,
+			VisitingTeam:    visitor,
+			HomeTeam:        home,
+			VisitingScore:   visitingScore,
+			HomeScore:       homeScore,
+			Attendance:      attendance,
+			DurationMinutes: minutes,
+			LengthInOuts:    outs,
+		}
+	}
+	return []*RetrosheetGame{
+		game(2019, "DEN02", "SFN", "COL", 6, 8, 40000, 190, 51),
+		game(2019, "DEN02", "SFN", "COL", 7, 5, 30000, 200, 54),
+		game(2019, "SFO03", "COL", "SFN", 1, 3, 35000, 150, 51),
+		game(2019, "SFO03", "COL", "SFN", 2, 1, 0, 0, 54),
+		game(2020, "SFO03", "COL", "SFN", 4, 5, 0, 250, 60),
+	}
+}
+
+func TestParkFactors(t *testing.T) {
+	parks := map[string]Park{"DEN02": {ID: "DEN02", Name: "Coors Field", City: "Denver", State: "CO"}}
+	factors := parkFactors(testParkGames(), parks, 2019, 2019, 2)
+	require.Len(t, factors, 2)
+
+	coors := factors[0]
+	assert.Equal(t, "Coors Field", coors.Park.Name)
+	assert.Equal(t, 13.0, coors.RunsPerGame())
+	assert.Equal(t, 0.5, coors.HomeWinPct())
+	// COL scored and allowed 3.5 runs a game on the road.
+	assert.InDelta(t, 371.4, coors.Factor(), 0.1)
+
+	oracle := factors[1]
+	assert.Equal(t, "SFO03", oracle.Park.Name)
+	assert.InDelta(t, 26.9, oracle.Factor(), 0.1)
+}
+
+func TestAttendanceAndGameLength(t *testing.T) {
+	attendance := attendanceBySeason(testParkGames(), FranchiseConverter{})
+	assert.Equal(t, []TeamAttendance{
+		{Year: 2019, Team: "COL", Franchise: "COL", Games: 2, Total: 70000},
+		{Year: 2019, Team: "SFN", Franchise: "SFN", Games: 1, Total: 35000},
+	}, attendance)
+
+	lengths := gameLengths(testParkGames())
+	assert.Equal(t, []GameLength{
+		{Year: 2019, Games: 3, Minutes: 540, NineInningGames: 3, NineInningMinutes: 540, LongestMinutes: 200},
+		{Year: 2020, Games: 1, Minutes: 250, LongestMinutes: 250},
+	}, lengths)
+}
+
+func TestGetParks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "parkcode.txt")
+	require.NoError(t, os.WriteFile(path, []byte("PARKID,NAME,AKA,CITY,STATE,START,END,LEAGUE,NOTES\nDEN02,Coors Field,,Denver,CO,04/26/1995,,NL,\n"), 0o644))
+	parks, err := getParks(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Park{"DEN02": {ID: "DEN02", Name: "Coors Field", City: "Denver", State: "CO"}}, parks)
+}
@@ -0,0 +1,256 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type RaceTeam struct {
+	Team        string
+	GamesBehind float64
+
+	// MagicNumber is the combination of this team's wins and its rivals'
+	// losses needed to clinch the table outright.
+	MagicNumber int
+
+	// EliminationNumber is the combination of this team's losses and the
+	// closest rival's wins that would eliminate it.
+	EliminationNumber int
+}
+
+type RaceDay struct {
+	Date  time.Time
+	Teams []RaceTeam // in standings order
+}
+
+type PennantRace struct {
+	Year     int
+	League   string
+	Division string
+	Winner   string
+
+	// Clinched is the date the winner's magic number reached zero. It is zero
+	// if the winner only finished on top after the final day.
+	Clinched time.Time
+
+	// Comeback is the largest number of games the winner trailed by.
+	Comeback     float64
+	ComebackDate time.Time
+
+	// BlownLead is the largest lead held by a team that did not win.
+	BlownLead     float64
+	BlownLeadTeam string
+	BlownLeadDate time.Time
+
+	Days []RaceDay
+}
+
+func (pr PennantRace) Name() string {
+	return fmt.Sprintf("%d %s", pr.Year, divisionName(pr.League, pr.Division))
+}
+
+// magicNumber is the number of team's wins plus rival's losses needed before
+// rival can no longer catch team.
+func magicNumber(team, rival StandingsEntry) int {
+	n := rival.Wins + rival.GamesRemaining - team.Wins + 1
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+func raceDay(standings Standings, table StandingsTable) RaceDay {
+	day := RaceDay{Date: standings.Date}
+	for i, entry := range table.Entries {
+		rt := RaceTeam{Team: entry.Team, GamesBehind: entry.GamesBehind}
+		rt.EliminationNumber = entry.Wins + entry.GamesRemaining + 1
+		for j, rival := range table.Entries {
+			if i == j {
+				continue
+			}
+			if n := magicNumber(entry, rival); n > rt.MagicNumber {
+				rt.MagicNumber = n
+			}
+			if n := magicNumber(rival, entry); n < rt.EliminationNumber {
+				rt.EliminationNumber = n
+			}
+		}
+		day.Teams = append(day.Teams, rt)
+	}
+	return day
+}
+
+// analyzePennantRaces follows every table through the season's standings
+// timeline.
+func analyzePennantRaces(year int, timeline []Standings) []PennantRace {
+	races := make(map[TeamInfo]*PennantRace)
+	var keys []TeamInfo
+	for _, standings := range timeline {
+		for _, table := range standings.Tables {
+			key := TeamInfo{League: table.League, Division: table.Division}
+			race, ok := races[key]
+			if !ok {
+				race = &PennantRace{Year: year, League: table.League, Division: table.Division}
+				races[key] = race
+				keys = append(keys, key)
+			}
+			race.Days = append(race.Days, raceDay(standings, table))
+		}
+	}
+
+	var result []PennantRace
+	for _, key := range keys {
+		race := races[key]
+		summarizeRace(race)
+		result = append(result, *race)
+	}
+	return result
+}
+
+func summarizeRace(race *PennantRace) {
+	if len(race.Days) == 0 {
+		return
+	}
+	final := race.Days[len(race.Days)-1]
+	race.Winner = final.Teams[0].Team
+	for _, day := range race.Days {
+		for i, rt := range day.Teams {
+			if rt.Team == race.Winner {
+				if race.Clinched.IsZero() && rt.MagicNumber == 0 && len(day.Teams) > 1 {
+					race.Clinched = day.Date
+				}
+				if rt.GamesBehind > race.Comeback {
+					race.Comeback = rt.GamesBehind
+					race.ComebackDate = day.Date
+				}
+				continue
+			}
+			if i != 0 || len(day.Teams) < 2 {
+				continue
+			}
+			if lead := day.Teams[1].GamesBehind - rt.GamesBehind; lead > race.BlownLead {
+				race.BlownLead = lead
+				race.BlownLeadTeam = rt.Team
+				race.BlownLeadDate = day.Date
+			}
+		}
+	}
+}
+
+func printPennantRace(race PennantRace, daily bool) {
+	fmt.Println(race.Name())
+	fmt.Println("Winner:", race.Winner)
+	if race.Clinched.IsZero() {
+		fmt.Println("Clinched: final day")
+	} else {
+		fmt.Println("Clinched:", race.Clinched.Format("2006-01-02"))
+	}
+	if race.Comeback > 0 {
+		fmt.Printf("Biggest deficit overcome: %.1f games on %s\n", race.Comeback, race.ComebackDate.Format("2006-01-02"))
+	}
+	if race.BlownLead > 0 {
+		fmt.Printf("Largest lead blown: %.1f games by %s on %s\n", race.BlownLead, race.BlownLeadTeam, race.BlownLeadDate.Format("2006-01-02"))
+	}
+	if daily {
+		for _, day := range race.Days {
+			fmt.Println(day.Date.Format("2006-01-02"))
+			for _, rt := range day.Teams {
+				fmt.Printf("  %-5s GB %5s  magic %3d  elim %3d\n", rt.Team, formatGamesBehind(rt.GamesBehind), rt.MagicNumber, rt.EliminationNumber)
+			}
+		}
+	}
+	fmt.Println()
+}
+
+// pennantRaceCmd represents the pennantRace command
+var pennantRaceCmd = &cobra.Command{
+	Use:   "pennantRace",
+	Short: "Magic numbers, clinch dates, comebacks and blown leads",
+	Long: `Follows each league and division race through the reconstructed standings.
+
+Inputs:
+
+year: The season to analyze. Each race's winner, clinch date, biggest deficit overcome and
+largest lead blown are printed.
+daily: Also print every team's games behind, magic number and elimination number per day.
+since: When no year is given, rank the biggest comebacks and blown leads of every season
+from this year on.
+top: How many races to list in each ranking.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+		daily, err := cmd.Flags().GetBool("daily")
+		if err != nil {
+			return err
+		}
+		since, err := cmd.Flags().GetInt("since")
+		if err != nil {
+			return err
+		}
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+
+		teamsBySeason, directory, err := loadStandingsData(rsDataDir)
+		if err != nil {
+			return err
+		}
+
+		if year != 0 {
+			engine, err := standingsEngineForYear(teamsBySeason, directory, year)
+			if err != nil {
+				return err
+			}
+			for _, race := range analyzePennantRaces(year, engine.Timeline()) {
+				printPennantRace(race, daily)
+			}
+			return nil
+		}
+
+		var races []PennantRace
+		for _, y := range teamsBySeason.Years() {
+			if y < since {
+				continue
+			}
+			engine, err := standingsEngineForYear(teamsBySeason, directory, y)
+			if err != nil {
+				return err
+			}
+			races = append(races, analyzePennantRaces(y, engine.Timeline())...)
+		}
+
+		sort.Slice(races, func(i, j int) bool { return races[i].Comeback > races[j].Comeback })
+		fmt.Println("Biggest comebacks")
+		for i := 0; i < top && i < len(races); i++ {
+			race := races[i]
+			fmt.Printf("%s: %s overcame %.1f games (%s)\n", race.Name(), race.Winner, race.Comeback, race.ComebackDate.Format("2006-01-02"))
+		}
+		fmt.Println()
+
+		sort.Slice(races, func(i, j int) bool { return races[i].BlownLead > races[j].BlownLead })
+		fmt.Println("Largest leads blown")
+		for i := 0; i < top && i < len(races); i++ {
+			race := races[i]
+			fmt.Printf("%s: %s led by %.1f games (%s), %s won\n", race.Name(), race.BlownLeadTeam, race.BlownLead, race.BlownLeadDate.Format("2006-01-02"), race.Winner)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pennantRaceCmd)
+	pennantRaceCmd.Flags().Int("year", 0, "season to analyze")
+	pennantRaceCmd.Flags().Bool("daily", false, "print magic and elimination numbers for every day")
+	pennantRaceCmd.Flags().Int("since", 0, "year to start ranking from when no year is given")
+	pennantRaceCmd.Flags().Int("top", 10, "number of races to list in each ranking")
+}
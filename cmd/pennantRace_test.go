@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMagicNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		team     StandingsEntry
+		rival    StandingsEntry
+		expected int
+	}{
+		{
+			name:     "race still open",
+			team:     StandingsEntry{Wins: 90, Losses: 60, GamesRemaining: 12},
+			rival:    StandingsEntry{Wins: 85, Losses: 65, GamesRemaining: 12},
+			expected: 8,
+		},
+		{
+			name:     "clinched",
+			team:     StandingsEntry{Wins: 100, Losses: 55, GamesRemaining: 7},
+			rival:    StandingsEntry{Wins: 85, Losses: 70, GamesRemaining: 7},
+			expected: 0,
+		},
+		{
+			name:     "rival can only tie",
+			team:     StandingsEntry{Wins: 95, Losses: 60, GamesRemaining: 7},
+			rival:    StandingsEntry{Wins: 88, Losses: 67, GamesRemaining: 7},
+			expected: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, magicNumber(test.team, test.rival))
+		})
+	}
+}
+
+func TestAnalyzePennantRaces(t *testing.T) {
+	directory := TeamDirectory{}
+	seasons := []*Season{
+		// AAA jumps out to a two game lead, then loses the rest.
+		testSeason("AAA", 2020, "NL", Win, Win, Loss, Loss, Loss, Loss),
+		testSeason("BBB", 2020, "NL", Loss, Loss, Win, Win, Win, Win),
+	}
+	engine := newStandingsEngine(seasons, directory)
+	races := analyzePennantRaces(2020, engine.Timeline())
+	require.Len(t, races, 1)
+
+	race := races[0]
+	assert.Equal(t, "BBB", race.Winner)
+	assert.Equal(t, 2.0, race.Comeback)
+	assert.Equal(t, time.Date(2020, time.April, 2, 0, 0, 0, 0, time.UTC), race.ComebackDate)
+	assert.Equal(t, "AAA", race.BlownLeadTeam)
+	assert.Equal(t, 2.0, race.BlownLead)
+	// BBB is 3-2 to AAA's 2-3 with a game left on April 5th, so one more BBB
+	// win or AAA loss is needed: AAA can still tie.
+	assert.Equal(t, time.Date(2020, time.April, 6, 0, 0, 0, 0, time.UTC), race.Clinched)
+	assert.Equal(t, RaceTeam{Team: "BBB", GamesBehind: 0, MagicNumber: 1, EliminationNumber: 3}, race.Days[4].Teams[0])
+	assert.Equal(t, RaceTeam{Team: "AAA", GamesBehind: 1, MagicNumber: 3, EliminationNumber: 1}, race.Days[4].Teams[1])
+}
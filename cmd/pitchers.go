@@ -0,0 +1,297 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type pitcherDecision struct {
+	game   *RetrosheetGame
+	result Result
+}
+
+// pitcherLog is every decision and save credited to one pitcher.
+type pitcherLog struct {
+	pitcher   Person
+	decisions []pitcherDecision
+	saves     []*RetrosheetGame
+}
+
+// loadPitcherLogs streams the game logs into each pitcher's decisions and
+// saves, each in the order played.
+func loadPitcherLogs(dir string) (map[string]*pitcherLog, error) {
+	var mu sync.Mutex
+	logs := make(map[string]*pitcherLog)
+	logFor := func(pitcher Person) *pitcherLog {
+		log, ok := logs[pitcher.ID]
+		if !ok {
+			log = &pitcherLog{pitcher: pitcher}
+			logs[pitcher.ID] = log
+		}
+		return log
+	}
+	err := ByRetrosheetGame(dir, func(game *RetrosheetGame) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if game.WinningPitcher.ID != "" {
+			log := logFor(game.WinningPitcher)
+			log.decisions = append(log.decisions, pitcherDecision{game: game, result: Win})
+		}
+		if game.LosingPitcher.ID != "" {
+			log := logFor(game.LosingPitcher)
+			log.decisions = append(log.decisions, pitcherDecision{game: game, result: Loss})
+		}
+		if game.SavingPitcher.ID != "" {
+			log := logFor(game.SavingPitcher)
+			log.saves = append(log.saves, game)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range logs {
+		decisions := log.decisions
+		sort.Slice(decisions, func(i, j int) bool { return retrosheetGameLess(decisions[i].game, decisions[j].game) })
+		saves := log.saves
+		sort.Slice(saves, func(i, j int) bool { return retrosheetGameLess(saves[i], saves[j]) })
+	}
+	return logs, nil
+}
+
+type PitcherSeason struct {
+	Year   int
+	Wins   int
+	Losses int
+	Saves  int
+}
+
+type PitcherRecord struct {
+	Pitcher Person
+	Wins    int
+	Losses  int
+	Saves   int
+	Seasons []PitcherSeason
+}
+
+func (log *pitcherLog) record() PitcherRecord {
+	pr := PitcherRecord{Pitcher: log.pitcher}
+	seasons := make(map[int]*PitcherSeason)
+	season := func(year int) *PitcherSeason {
+		ps, ok := seasons[year]
+		if !ok {
+			ps = &PitcherSeason{Year: year}
+			seasons[year] = ps
+		}
+		return ps
+	}
+	for _, decision := range log.decisions {
+		ps := season(decision.game.Date.Year())
+		if decision.result == Win {
+			pr.Wins++
+			ps.Wins++
+		} else {
+			pr.Losses++
+			ps.Losses++
+		}
+	}
+	for _, game := range log.saves {
+		pr.Saves++
+		season(game.Date.Year()).Saves++
+	}
+	for _, ps := range seasons {
+		pr.Seasons = append(pr.Seasons, *ps)
+	}
+	sort.Slice(pr.Seasons, func(i, j int) bool { return pr.Seasons[i].Year < pr.Seasons[j].Year })
+	return pr
+}
+
+type PitcherStreak struct {
+	Pitcher Person
+	WinStreak
+}
+
+// longestPitcherStreaks returns each pitcher's longest run of consecutive
+// winning decisions, longest first. Games without a decision don't break a
+// streak.
+func longestPitcherStreaks(logs map[string]*pitcherLog, top int) []PitcherStreak {
+	var streaks []PitcherStreak
+	for _, log := range logs {
+		decisions := log.decisions
+		best := longestWinStreak(len(decisions), func(i int) (Result, time.Time) { return decisions[i].result, decisions[i].game.Date })
+		if best.Wins > 0 {
+			streaks = append(streaks, PitcherStreak{Pitcher: log.pitcher, WinStreak: best})
+		}
+	}
+	return streaks[:rankStreaks(streaks, len(streaks), top, func(i int) WinStreak { return streaks[i].WinStreak })]
+}
+
+type SavesLeader struct {
+	Pitcher Person
+	Saves   int
+}
+
+// savesLeaders returns the pitchers with the most saves in a season.
+func savesLeaders(logs map[string]*pitcherLog, year, top int) []SavesLeader {
+	var leaders []SavesLeader
+	for _, log := range logs {
+		var saves int
+		for _, game := range log.saves {
+			if game.Date.Year() == year {
+				saves++
+			}
+		}
+		if saves > 0 {
+			leaders = append(leaders, SavesLeader{Pitcher: log.pitcher, Saves: saves})
+		}
+	}
+	sort.Slice(leaders, func(i, j int) bool {
+		if leaders[i].Saves != leaders[j].Saves {
+			return leaders[i].Saves > leaders[j].Saves
+		}
+		return leaders[i].Pitcher.ID < leaders[j].Pitcher.ID
+	})
+	if len(leaders) > top {
+		leaders = leaders[:top]
+	}
+	return leaders
+}
+
+// StarterRecords returns the team's record in each pitcher's starts, most
+// starts first.
+func (s Season) StarterRecords() []PersonRecord {
+	return s.recordsBy(func(game TeamGame) Person { return game.StartingPitcher })
+}
+
+// pitchersCmd represents the pitchers command
+var pitchersCmd = &cobra.Command{
+	Use:   "pitchers",
+	Short: "Starting pitchers and pitching decisions from the game logs",
+	Long: `Analyses of the starting pitchers and the winning, losing and saving pitchers named in
+every game log. Pitchers are identified by their Retrosheet ID, e.g. kersc001.`,
+}
+
+var pitcherRecordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "A pitcher's career and per-season W-L and saves",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := cmd.Flags().GetString("pitcher")
+		if err != nil {
+			return err
+		}
+		logs, err := loadPitcherLogs(rsDataDir)
+		if err != nil {
+			return err
+		}
+		log, ok := logs[id]
+		if !ok {
+			return fmt.Errorf("no decisions found for pitcher %s", id)
+		}
+		pr := log.record()
+		fmt.Printf("%s: %d-%d, %d saves\n", pr.Pitcher.Name, pr.Wins, pr.Losses, pr.Saves)
+		for _, ps := range pr.Seasons {
+			fmt.Printf("%d\t%d-%d\t%d\n", ps.Year, ps.Wins, ps.Losses, ps.Saves)
+		}
+		return nil
+	},
+}
+
+var pitcherStartersCmd = &cobra.Command{
+	Use:   "starters",
+	Short: "A team-season's record by starting pitcher",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		franchise, err := cmd.Flags().GetString("franchise")
+		if err != nil {
+			return err
+		}
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		season, ok := teamsBySeason.m[franchise][year]
+		if !ok {
+			return fmt.Errorf("no games found for %s in %d", franchise, year)
+		}
+		fmt.Println("Pitcher\tStarts\tRecord\tPct")
+		for _, sr := range season.StarterRecords() {
+			name := sr.Person.Name
+			if name == "" {
+				name = "(unknown)"
+			}
+			fmt.Printf("%s\t%d\t%s\t%s\n", name, sr.Games, sr.Record, formatPct(sr.Record.Pct()))
+		}
+		return nil
+	},
+}
+
+var pitcherStreaksCmd = &cobra.Command{
+	Use:   "streaks",
+	Short: "Longest runs of consecutive winning decisions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+		logs, err := loadPitcherLogs(rsDataDir)
+		if err != nil {
+			return err
+		}
+		for _, streak := range longestPitcherStreaks(logs, top) {
+			fmt.Printf("%s\t%d\t%s to %s\n", streak.Pitcher.Name, streak.Wins, streak.Start.Format("2006-01-02"), streak.End.Format("2006-01-02"))
+		}
+		return nil
+	},
+}
+
+var pitcherSavesCmd = &cobra.Command{
+	Use:   "saves",
+	Short: "Saves leaders for a season",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+		logs, err := loadPitcherLogs(rsDataDir)
+		if err != nil {
+			return err
+		}
+		for _, leader := range savesLeaders(logs, year, top) {
+			fmt.Printf("%s\t%d\n", leader.Pitcher.Name, leader.Saves)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pitchersCmd)
+	pitchersCmd.AddCommand(pitcherRecordCmd, pitcherStartersCmd, pitcherStreaksCmd, pitcherSavesCmd)
+
+	pitcherRecordCmd.Flags().String("pitcher", "", "pitcher's Retrosheet ID")
+	pitcherRecordCmd.MarkFlagRequired("pitcher")
+
+	pitcherStartersCmd.Flags().String("franchise", "", "franchise to report on")
+	pitcherStartersCmd.Flags().Int("year", 0, "season to report on")
+	pitcherStartersCmd.MarkFlagRequired("franchise")
+	pitcherStartersCmd.MarkFlagRequired("year")
+
+	pitcherStreaksCmd.Flags().Int("top", 10, "number of streaks to list")
+
+	pitcherSavesCmd.Flags().Int("year", 0, "season to rank")
+	pitcherSavesCmd.Flags().Int("top", 10, "number of pitchers to list")
+	pitcherSavesCmd.MarkFlagRequired("year")
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPitcherLogs(t *testing.T) {
+	logs, err := loadPitcherLogs("./test_data")
+	require.NoError(t, err)
+
+	cole, ok := logs["coleg001"]
+	require.True(t, ok)
+	assert.Equal(t, PitcherRecord{
+		Pitcher: Person{ID: "coleg001", Name: "Gerrit Cole"},
+		Wins:    2,
+		Seasons: []PitcherSeason{{Year: 2000, Wins: 1}, {Year: 2001, Wins: 1}},
+	}, cole.record())
+
+	scherzer := logs["schem001"].record()
+	assert.Equal(t, 0, scherzer.Wins)
+	assert.Equal(t, 2, scherzer.Losses)
+
+	streaks := longestPitcherStreaks(logs, 3)
+	require.Len(t, streaks, 3)
+	for _, streak := range streaks {
+		assert.Equal(t, 2, streak.Wins)
+	}
+	assert.Equal(t, time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC), streaks[0].Start)
+}
+
+func TestSavesLeaders(t *testing.T) {
+	game := func(year int) *RetrosheetGame {
+		return &RetrosheetGame{Date: time.Date(year, time.June, 1, 0, 0, 0, 0, time.UTC)}
+	}
+	closer := Person{ID: "clos001", Name: "Closer"}
+	setup := Person{ID: "setu001", Name: "Setup Man"}
+	logs := map[string]*pitcherLog{
+		closer.ID: {pitcher: closer, saves: []*RetrosheetGame{game(2020), game(2021), game(2021), game(2021)}},
+		setup.ID:  {pitcher: setup, saves: []*RetrosheetGame{game(2021)}},
+	}
+	assert.Equal(t, []SavesLeader{{Pitcher: closer, Saves: 3}, {Pitcher: setup, Saves: 1}}, savesLeaders(logs, 2021, 10))
+	assert.Equal(t, []SavesLeader{{Pitcher: closer, Saves: 3}}, savesLeaders(logs, 2021, 1))
+	assert.Empty(t, savesLeaders(logs, 2019, 10))
+}
+
+func TestStarterRecords(t *testing.T) {
+	ace := Person{ID: "ace001", Name: "Ace"}
+	fifth := Person{ID: "fift001", Name: "Fifth Starter"}
+	season := testSeason("LAN", 2021, "NL", Win, Win, Loss, Loss, Win, Loss)
+	for i := range season.Games {
+		season.Games[i].StartingPitcher = ace
+		if i%2 == 1 {
+			season.Games[i].StartingPitcher = fifth
+		}
+	}
+	season.Games[5].StartingPitcher = Person{}
+	assert.Equal(t, []PersonRecord{
+		{Person: ace, Games: 3, Record: SeasonRecord{Wins: 2, Losses: 1}},
+		{Person: fifth, Games: 2, Record: SeasonRecord{Wins: 1, Losses: 1}},
+	}, season.StarterRecords())
+}
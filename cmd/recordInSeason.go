@@ -6,6 +6,7 @@ package cmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
 
 	"github.com/spf13/cobra"
 )
@@ -39,7 +40,7 @@ to quickly create a Cobra application.`,
 			return err
 		}
 
-		teamsBySeason, err := GetTeamsBySeason("cmd/rs_data")
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
 		if err != nil {
 			return err
 		}
@@ -57,6 +58,20 @@ to quickly create a Cobra application.`,
 			return matchingSeasons[i].Season.GetSeasonRecord().Wins < matchingSeasons[j].Season.GetSeasonRecord().Wins
 		})
 
+		htmlPath, err := cmd.Flags().GetString("html")
+		if err != nil {
+			return err
+		}
+		if htmlPath != "" {
+			report := Report{
+				Title:    fmt.Sprintf("Seasons with a %d-%d stretch", wins, losses),
+				Sections: []ReportSection{recordHitsReportSection(matchingSeasons)},
+			}
+			if err := writeReportFile(htmlPath, report); err != nil {
+				return err
+			}
+		}
+
 		for _, subset := range matchingSeasons {
 			fmt.Println(subset.Season.Franchise, subset.Season.Year, "Start", subset.Start, "End", subset.End, "Record", subset.Season.GetSeasonRecord().String())
 		}
@@ -98,8 +113,27 @@ func seasonMatchesRecord(season *Season, wins, losses, since int) *SeasonSubset
 	return nil
 }
 
+// recordHitsReportSection tabulates the first matching stretch of each
+// season alongside the season's final record.
+func recordHitsReportSection(subsets []*SeasonSubset) ReportSection {
+	table := &ReportTable{Columns: []string{"Franchise", "Year", "Start", "End", "Season record", "Season pct"}}
+	for _, subset := range subsets {
+		record := subset.Season.GetSeasonRecord()
+		table.Rows = append(table.Rows, []string{
+			subset.Season.Franchise,
+			strconv.Itoa(subset.Season.Year),
+			strconv.Itoa(subset.Start),
+			strconv.Itoa(subset.End),
+			record.String(),
+			formatPct(record.Pct()),
+		})
+	}
+	return ReportSection{Heading: "Matching seasons", Table: table}
+}
+
 func init() {
 	rootCmd.AddCommand(recordInSeasonCmd)
+	recordInSeasonCmd.Flags().String("html", "", "also write the seasons to this HTML report file")
 	recordInSeasonCmd.Flags().Int("wins", 0, "wins within record")
 	recordInSeasonCmd.Flags().Int("losses", 0, "losses within record")
 	recordInSeasonCmd.Flags().Int("since", 0, "year to start tracking")
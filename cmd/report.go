@@ -0,0 +1,116 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"html/template"
+	"io"
+	"os"
+)
+
+// Report is a self-contained HTML page of an analysis's results. It needs no
+// external stylesheets, scripts or images, so the file can be shared alone.
+type Report struct {
+	Title    string
+	Sections []ReportSection
+}
+
+// ReportSection is one heading of a report, with an optional chart and
+// table.
+type ReportSection struct {
+	Heading string
+	Notes   string
+	// Chart is inline SVG, such as LineChart.SVG returns.
+	Chart template.HTML
+	Table *ReportTable
+}
+
+// ReportTable is a table whose columns sort when their header is clicked.
+// Columns whose cells all read as numbers sort numerically.
+type ReportTable struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// chartSection wraps a chart in a report section.
+func chartSection(heading string, chart LineChart) ReportSection {
+	return ReportSection{Heading: heading, Chart: template.HTML(chart.SVG())}
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #202020; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #d0d0d0; padding: 4px 10px; text-align: left; }
+th { background: #f0f0f0; cursor: pointer; user-select: none; }
+th[data-order="asc"]::after { content: " \25B2"; }
+th[data-order="desc"]::after { content: " \25BC"; }
+tr:nth-child(even) td { background: #fafafa; }
+.notes { color: #606060; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Sections}}<section>
+<h2>{{.Heading}}</h2>
+{{if .Notes}}<p class="notes">{{.Notes}}</p>
+{{end}}{{if .Chart}}{{.Chart}}
+{{end}}{{with .Table}}<table class="sortable">
+<thead><tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+{{end}}</section>
+{{end}}<script>
+function cellNumber(text) {
+  text = text.trim();
+  return /^[+-]?(\d+\.?\d*|\.\d+)%?$/.test(text) ? parseFloat(text.replace(/^\+/, "")) : null;
+}
+document.querySelectorAll("table.sortable").forEach(function (table) {
+  table.querySelectorAll("th").forEach(function (th, column) {
+    th.addEventListener("click", function () {
+      var order = th.dataset.order === "asc" ? "desc" : "asc";
+      table.querySelectorAll("th").forEach(function (other) { delete other.dataset.order; });
+      th.dataset.order = order;
+      var body = table.tBodies[0];
+      var rows = Array.prototype.slice.call(body.rows);
+      var texts = rows.map(function (row) { return row.cells[column].textContent; });
+      var numeric = texts.every(function (text) { return cellNumber(text) !== null; });
+      var keyed = rows.map(function (row, i) {
+        return { row: row, key: numeric ? cellNumber(texts[i]) : texts[i] };
+      });
+      keyed.sort(function (a, b) {
+        var c = numeric ? a.key - b.key : a.key.localeCompare(b.key);
+        return order === "asc" ? c : -c;
+      });
+      keyed.forEach(function (k) { body.appendChild(k.row); });
+    });
+  });
+});
+</script>
+</body>
+</html>
+`))
+
+func (r Report) Write(w io.Writer) error {
+	return reportTemplate.Execute(w, r)
+}
+
+// writeReportFile writes the report to a new file at path.
+func writeReportFile(path string, r Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := r.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
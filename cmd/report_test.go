@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportWrite(t *testing.T) {
+	report := Report{
+		Title: "Streaks <2021>",
+		Sections: []ReportSection{
+			chartSection("Chart", LineChart{Series: []ChartSeries{{Label: "2021 SFN", Points: []ChartPoint{{X: 1, Y: 1}}}}}),
+			{
+				Heading: "Table",
+				Notes:   "Ties left out",
+				Table:   &ReportTable{Columns: []string{"Team", "Wins"}, Rows: [][]string{{"SFN", "107"}, {"<b>", "1"}}},
+			},
+		},
+	}
+	var b bytes.Buffer
+	require.NoError(t, report.Write(&b))
+	out := b.String()
+	assert.Contains(t, out, "<title>Streaks &lt;2021&gt;</title>")
+	assert.Contains(t, out, "<svg ", "the chart is inlined unescaped")
+	assert.Contains(t, out, ">2021 SFN<")
+	assert.Contains(t, out, `<p class="notes">Ties left out</p>`)
+	assert.Contains(t, out, "<th>Team</th><th>Wins</th>")
+	assert.Contains(t, out, "<td>SFN</td><td>107</td>")
+	assert.Contains(t, out, "<td>&lt;b&gt;</td>", "cells are escaped")
+	assert.Contains(t, out, `<table class="sortable">`)
+	assert.NotContains(t, out, "src=", "no external assets")
+	assert.NotContains(t, out, "href=")
+	assert.Equal(t, 1, strings.Count(out, "<script>"))
+}
+
+func TestMatchReportSection(t *testing.T) {
+	combos := newGameCombos()
+	combos.Add("WWL", seasonDetails{season: "2000", team: "SFN", length: 3, gameStart: 1, gameEnd: 3})
+	combos.Add("WWL", seasonDetails{season: "2001", team: "LAN", length: 3, gameStart: 4, gameEnd: 6})
+	combos.Add("WL", seasonDetails{season: "2000", team: "SFN", length: 2, gameStart: 2, gameEnd: 3})
+	combos.Add("WL", seasonDetails{season: "2001", team: "LAN", length: 2, gameStart: 5, gameEnd: 6})
+	combos.Add("LL", seasonDetails{season: "2001", team: "LAN", length: 2, gameStart: 1, gameEnd: 2})
+	combos.Sort()
+
+	section := matchReportSection(combos, nil)
+	assert.Equal(t, []string{"Games", "Record", "Results", "Seasons"}, section.Table.Columns)
+	assert.Equal(t, [][]string{
+		{"3", "2-1", "WWL", "2000 SFN games 1-3; 2001 LAN games 4-6"},
+		{"2", "1-1", "WL", "2000 SFN games 2-3; 2001 LAN games 5-6"},
+	}, section.Table.Rows)
+
+	section = matchReportSection(combos, []int{2, 3, 3, 4})
+	assert.Equal(t, "P-value", section.Table.Columns[4])
+	assert.Equal(t, "0.7500", section.Table.Rows[0][4])
+}
+
+func TestInningReport(t *testing.T) {
+	report := inningReport([]inningOutscorePerSeason{
+		{season: 2000, weirdGames: 1, totalGames: 4},
+		{season: 2001, weirdGames: 0, totalGames: 4},
+	})
+	require.Len(t, report.Sections, 1)
+	section := report.Sections[0]
+	assert.Equal(t, [][]string{{"2000", "4", "1", "25.0"}, {"2001", "4", "0", "0.0"}}, section.Table.Rows)
+	assert.Contains(t, string(section.Chart), "<polyline")
+}
@@ -22,6 +22,10 @@ const (
 	Tie
 )
 
+// rsDataDir is the Retrosheet data directory the analysis commands read
+// from, chosen with the root command's --data-dir flag.
+var rsDataDir = "cmd/rs_data"
+
 type FranchiseConverter map[string]string
 
 func (fc FranchiseConverter) Convert(team string) string {
@@ -33,59 +37,338 @@ func (fc FranchiseConverter) Convert(team string) string {
 }
 
 type TeamGame struct {
-	Date               time.Time
-	Team               string
-	Franchise          string
-	OpponentTeam       string
-	OpponentFranchise  string
-	OpponentGameNumber int
-	TeamGameNumber     int
-	OpponentScore      int
-	TeamScore          int
-	ForfeitInfo        string
-	OpponentLineScore  string
-	TeamLineScore      string
-	Result             Result
+	GameID                  string
+	Date                    time.Time
+	GameNumber              int
+	Team                    string
+	Franchise               string
+	League                  string
+	OpponentTeam            string
+	OpponentFranchise       string
+	OpponentLeague          string
+	OpponentGameNumber      int
+	TeamGameNumber          int
+	Manager                 Person
+	OpponentManager         Person
+	StartingPitcher         Person
+	OpponentStartingPitcher Person
+	Lineup                  Lineup
+	OpponentLineup          Lineup
+	Stats                   TeamStats
+	OpponentStats           TeamStats
+	IsHome                  bool
+	DayNight                DayNight
+	LengthInOuts            int
+	OpponentScore           int
+	TeamScore               int
+	Forfeit                 Forfeit
+	Protest                 Protest
+	OpponentLineScore       string
+	TeamLineScore           string
+	Result                  Result
+	// ResultDate is the day the game ended, later than Date for a suspended
+	// game.
+	ResultDate time.Time
 }
 
 type RetrosheetGame struct {
-	Date               time.Time
+	Date time.Time
+	// GameNumber is 0 for a single game and 1 or 2 for the games of a
+	// doubleheader (3 for the few tripleheaders).
+	GameNumber         int
 	VisitingTeam       string
+	VisitingLeague     string
 	VisitingGameNumber int
 	HomeTeam           string
+	HomeLeague         string
 	HomeGameNumber     int
 	VisitingScore      int
 	HomeScore          int
-	ForfeitInfo        string
-	VisitingLineScore  string
-	HomeLineScore      string
+	DayNight           DayNight
+	// LengthInOuts is 0 when Retrosheet doesn't know the game's length.
+	LengthInOuts int
+	ParkID       string
+	// Attendance and DurationMinutes are 0 when unknown. Retrosheet leaves
+	// attendance blank for the games played without fans in 2020.
+	Attendance        int
+	DurationMinutes   int
+	Completion        *Completion
+	Forfeit           Forfeit
+	Protest           Protest
+	VisitingLineScore string
+	HomeLineScore     string
+	VisitingStats     TeamStats
+	HomeStats         TeamStats
+	VisitingManager   Person
+	HomeManager       Person
+	// SavingPitcher is the zero Person when no save was awarded.
+	WinningPitcher          Person
+	LosingPitcher           Person
+	SavingPitcher           Person
+	VisitingStartingPitcher Person
+	HomeStartingPitcher     Person
+	Umpires                 Umpires
+	VisitingLineup          Lineup
+	HomeLineup              Lineup
+}
+
+// TeamStats is a team's box score from a game log: its offense, its
+// pitching and its defense. Every field is 0 when Retrosheet doesn't have the
+// game's box score.
+type TeamStats struct {
+	AtBats                  int
+	Hits                    int
+	Doubles                 int
+	Triples                 int
+	HomeRuns                int
+	RBI                     int
+	SacHits                 int
+	SacFlies                int
+	HitByPitch              int
+	Walks                   int
+	IntentionalWalks        int
+	Strikeouts              int
+	StolenBases             int
+	CaughtStealing          int
+	GroundedIntoDoublePlays int
+	CatcherInterference     int
+	LeftOnBase              int
+
+	PitchersUsed         int
+	IndividualEarnedRuns int
+	TeamEarnedRuns       int
+	WildPitches          int
+	Balks                int
+
+	Putouts     int
+	Assists     int
+	Errors      int
+	PassedBalls int
+	DoublePlays int
+	TriplePlays int
+}
+
+// fields lists the stats in the order of the game log's 28 field block.
+func (ts *TeamStats) fields() []*int {
+	return []*int{
+		&ts.AtBats, &ts.Hits, &ts.Doubles, &ts.Triples, &ts.HomeRuns, &ts.RBI,
+		&ts.SacHits, &ts.SacFlies, &ts.HitByPitch, &ts.Walks, &ts.IntentionalWalks,
+		&ts.Strikeouts, &ts.StolenBases, &ts.CaughtStealing, &ts.GroundedIntoDoublePlays,
+		&ts.CatcherInterference, &ts.LeftOnBase,
+		&ts.PitchersUsed, &ts.IndividualEarnedRuns, &ts.TeamEarnedRuns, &ts.WildPitches, &ts.Balks,
+		&ts.Putouts, &ts.Assists, &ts.Errors, &ts.PassedBalls, &ts.DoublePlays, &ts.TriplePlays,
+	}
+}
+
+// Known reports whether the game's box score is known.
+func (ts TeamStats) Known() bool {
+	return ts.AtBats > 0
+}
+
+func (ts TeamStats) PlateAppearances() int {
+	return ts.AtBats + ts.Walks + ts.HitByPitch + ts.SacHits + ts.SacFlies + ts.CatcherInterference
+}
+
+// Umpires is a game's umpiring crew. Positions nobody worked are the zero
+// Person.
+type Umpires struct {
+	HomePlate  Person
+	FirstBase  Person
+	SecondBase Person
+	ThirdBase  Person
+	LeftField  Person
+	RightField Person
+}
+
+// Lineup is a team's starting batting order. It is the zero Lineup when
+// Retrosheet doesn't know who started.
+type Lineup [9]LineupSlot
+
+// LineupSlot is a starting batter and the fielding position they started at,
+// numbered as scorers do: 1 for pitcher through 9 for right field, and 10 for
+// designated hitter.
+type LineupSlot struct {
+	Player   Person
+	Position int
+}
+
+func (l Lineup) Known() bool {
+	return l[0].Player.ID != ""
+}
+
+// Person is someone named in a game log, by Retrosheet ID and name.
+type Person struct {
+	ID   string
+	Name string
+}
+
+// person reads an ID and name pair, returning the zero Person when the ID is
+// blank. Retrosheet names nobody "(none)".
+func person(id, name string) Person {
+	if id == "" {
+		return Person{}
+	}
+	return Person{ID: id, Name: name}
+}
+
+type DayNight string
+
+const (
+	UnknownDayNight DayNight = ""
+	Day             DayNight = "D"
+	Night           DayNight = "N"
+)
+
+// Completion describes a game suspended on Date and finished later.
+type Completion struct {
+	Date time.Time
+	Park string
+	// VisitingScore, HomeScore and Outs are as of the suspension.
+	VisitingScore int
+	HomeScore     int
+	Outs          int
+}
+
+// Forfeit is Retrosheet's forfeit code, naming the team awarded the game.
+type Forfeit string
+
+const (
+	NoForfeit         Forfeit = ""
+	ForfeitToVisitor  Forfeit = "V"
+	ForfeitToHome     Forfeit = "H"
+	ForfeitNoDecision Forfeit = "T"
+)
+
+// Protest is Retrosheet's protest code.
+type Protest string
+
+const (
+	NoProtest              Protest = ""
+	ProtestByUnknown       Protest = "P"
+	ProtestByVisitor       Protest = "V"
+	ProtestByHome          Protest = "H"
+	UpheldProtestByVisitor Protest = "X"
+	UpheldProtestByHome    Protest = "Y"
+)
+
+func (p Protest) Upheld() bool {
+	return p == UpheldProtestByVisitor || p == UpheldProtestByHome
+}
+
+// ResultMode chooses between a forfeited game's official result and the score
+// on the field when it was forfeited.
+type ResultMode int
+
+const (
+	OfficialResult ResultMode = iota
+	OnFieldResult
+)
+
+func parseResultMode(mode string) (ResultMode, error) {
+	switch mode {
+	case "official":
+		return OfficialResult, nil
+	case "on-field":
+		return OnFieldResult, nil
+	}
+	return 0, fmt.Errorf("unknown result mode %q, expected official or on-field", mode)
+}
+
+// resultMode is the mode chosen with the root command's --result flag.
+var resultMode = OfficialResult
+
+// GameID identifies a game the way Retrosheet does: home team, date and game
+// number, e.g. CHN202204070.
+func (rg RetrosheetGame) GameID() string {
+	return fmt.Sprintf("%s%s%d", rg.HomeTeam, rg.Date.Format("20060102"), rg.GameNumber)
+}
+
+// retrosheetGameLess orders games by date, then by home team and game number
+// so a doubleheader's games are in the order they were played.
+func retrosheetGameLess(a, b *RetrosheetGame) bool {
+	if !a.Date.Equal(b.Date) {
+		return a.Date.Before(b.Date)
+	}
+	if a.HomeTeam != b.HomeTeam {
+		return a.HomeTeam < b.HomeTeam
+	}
+	if a.GameNumber != b.GameNumber {
+		return a.GameNumber < b.GameNumber
+	}
+	return a.HomeGameNumber < b.HomeGameNumber
+}
+
+// ResultDate is the day the game ended.
+func (rg RetrosheetGame) ResultDate() time.Time {
+	if rg.Completion != nil {
+		return rg.Completion.Date
+	}
+	return rg.Date
+}
+
+// scheduledInnings is how many innings a game was scheduled for: seven for
+// the games of a doubleheader in 2020 and 2021, nine otherwise.
+func scheduledInnings(date time.Time, gameNumber int) int {
+	if year := date.Year(); (year == 2020 || year == 2021) && gameNumber > 0 {
+		return 7
+	}
+	return 9
+}
+
+func (rg RetrosheetGame) ScheduledInnings() int {
+	return scheduledInnings(rg.Date, rg.GameNumber)
+}
+
+func (tg TeamGame) ScheduledInnings() int {
+	return scheduledInnings(tg.Date, tg.GameNumber)
+}
+
+// ExtraInnings reports whether the game went past its scheduled innings. It
+// is false when the game's length is unknown.
+func (tg TeamGame) ExtraInnings() bool {
+	return tg.LengthInOuts > 6*tg.ScheduledInnings()
 }
 
 func (rg RetrosheetGame) GetHomeResult() Result {
-	return calcRetrosheetResult(rg.HomeScore, rg.VisitingScore, true, rg.ForfeitInfo)
+	return rg.HomeResult(OfficialResult)
 }
 
 func (rg RetrosheetGame) GetVisitorResult() Result {
-	return calcRetrosheetResult(rg.VisitingScore, rg.HomeScore, false, rg.ForfeitInfo)
+	return rg.VisitorResult(OfficialResult)
+}
+
+func (rg RetrosheetGame) HomeResult(mode ResultMode) Result {
+	return calcRetrosheetResult(rg.HomeScore, rg.VisitingScore, true, rg.forfeit(mode))
 }
 
-func calcRetrosheetResult(teamScore, oppoScore int, isHome bool, forfeitInfo string) Result {
-	if forfeitInfo != "" {
-		if forfeitInfo == "T" {
+func (rg RetrosheetGame) VisitorResult(mode ResultMode) Result {
+	return calcRetrosheetResult(rg.VisitingScore, rg.HomeScore, false, rg.forfeit(mode))
+}
+
+func (rg RetrosheetGame) forfeit(mode ResultMode) Forfeit {
+	if mode == OnFieldResult {
+		return NoForfeit
+	}
+	return rg.Forfeit
+}
+
+func calcRetrosheetResult(teamScore, oppoScore int, isHome bool, forfeit Forfeit) Result {
+	if forfeit != NoForfeit {
+		if forfeit == ForfeitNoDecision {
 			return Tie
 		}
 		if isHome {
-			if forfeitInfo == "H" {
+			if forfeit == ForfeitToHome {
 				return Win
 			}
-			if forfeitInfo == "V" {
+			if forfeit == ForfeitToVisitor {
 				return Loss
 			}
 		} else {
-			if forfeitInfo == "H" {
+			if forfeit == ForfeitToHome {
 				return Loss
 			}
-			if forfeitInfo == "V" {
+			if forfeit == ForfeitToVisitor {
 				return Win
 			}
 		}
@@ -100,39 +383,169 @@ func calcRetrosheetResult(teamScore, oppoScore int, isHome bool, forfeitInfo str
 	return Loss
 }
 
-func (rg RetrosheetGame) LineScoreProcessed(linescore string) ([]int, error) {
-	var lookingForEnd bool
-	var stringSoFar string
-	scores := make([]int, 0, 9)
-	for _, r := range linescore {
-		if r == 'x' {
-			continue
+// InningStatus says what a line score records for one inning.
+type InningStatus int
+
+const (
+	Batted InningStatus = iota
+	// DidNotBat is the "x" of a home team that led after the top of the final
+	// inning.
+	DidNotBat
+	// RunsUnknown is an inning Retrosheet left blank in an otherwise known
+	// line score.
+	RunsUnknown
+)
+
+type LineScoreInning struct {
+	Status InningStatus
+	Runs   int
+}
+
+// LineScore is a team's parsed runs by inning. Retrosheet leaves the line
+// score blank for many old games, in which case Unknown is set and there are
+// no innings, and leaves single innings blank in a few others.
+type LineScore struct {
+	Unknown bool
+	Innings []LineScoreInning
+}
+
+// Complete reports whether the runs of every inning are known.
+func (ls LineScore) Complete() bool {
+	if ls.Unknown {
+		return false
+	}
+	for _, inning := range ls.Innings {
+		if inning.Status == RunsUnknown {
+			return false
 		}
-		if r == '(' {
-			lookingForEnd = true
-			continue
+	}
+	return true
+}
+
+// Runs returns the runs scored in each inning the team batted, leaving out
+// innings whose runs are unknown.
+func (ls LineScore) Runs() []int {
+	runs := make([]int, 0, len(ls.Innings))
+	for _, inning := range ls.Innings {
+		if inning.Status == Batted {
+			runs = append(runs, inning.Runs)
 		}
-		if r == ')' {
-			lookingForEnd = false
-			score, err := strconv.Atoi(stringSoFar)
-			if err != nil {
-				return nil, err
+	}
+	return runs
+}
+
+func (ls LineScore) Total() int {
+	return sumRuns(ls.Runs())
+}
+
+// LineScoreSyntaxError reports a line score that isn't made of single digit
+// innings, parenthesized multi-digit innings, blank innings and a trailing
+// "x".
+type LineScoreSyntaxError struct {
+	LineScore string
+	Offset    int
+	Reason    string
+}
+
+func (e *LineScoreSyntaxError) Error() string {
+	return fmt.Sprintf("line score %q: %s at offset %d", e.LineScore, e.Reason, e.Offset)
+}
+
+// LineScoreMismatchError reports a line score whose runs don't add up to the
+// team's final score.
+type LineScoreMismatchError struct {
+	Date          time.Time
+	VisitingTeam  string
+	HomeTeam      string
+	Team          string
+	LineScore     string
+	LineScoreRuns int
+	Score         int
+}
+
+func (e *LineScoreMismatchError) Error() string {
+	return fmt.Sprintf("%s %s @ %s: %s line score %q adds up to %d but the score is %d",
+		e.Date.Format("2006-01-02"), e.VisitingTeam, e.HomeTeam, e.Team, e.LineScore, e.LineScoreRuns, e.Score)
+}
+
+// ParseLineScore parses a Retrosheet line score such as "0010(10)002x". A
+// space is an inning whose runs are unknown.
+func ParseLineScore(linescore string) (LineScore, error) {
+	if linescore == "" {
+		return LineScore{Unknown: true}, nil
+	}
+	var ls LineScore
+	for i := 0; i < len(linescore); i++ {
+		syntaxError := func(reason string) error {
+			return &LineScoreSyntaxError{LineScore: linescore, Offset: i, Reason: reason}
+		}
+		if len(ls.Innings) > 0 && ls.Innings[len(ls.Innings)-1].Status == DidNotBat {
+			return LineScore{}, syntaxError("inning after x")
+		}
+		switch c := linescore[i]; {
+		case c == 'x':
+			ls.Innings = append(ls.Innings, LineScoreInning{Status: DidNotBat})
+		case c == ' ':
+			ls.Innings = append(ls.Innings, LineScoreInning{Status: RunsUnknown})
+		case c == '(':
+			end := strings.IndexByte(linescore[i:], ')')
+			if end < 0 {
+				return LineScore{}, syntaxError("unclosed parenthesis")
 			}
-			scores = append(scores, score)
-			stringSoFar = ""
+			runs, err := strconv.Atoi(linescore[i+1 : i+end])
+			if err != nil || runs < 0 {
+				return LineScore{}, syntaxError("invalid runs in parentheses")
+			}
+			ls.Innings = append(ls.Innings, LineScoreInning{Runs: runs})
+			i += end
+		case c >= '0' && c <= '9':
+			ls.Innings = append(ls.Innings, LineScoreInning{Runs: int(c - '0')})
+		default:
+			return LineScore{}, syntaxError(fmt.Sprintf("unexpected character %q", c))
+		}
+	}
+	return ls, nil
+}
+
+// LineScores parses both teams' line scores and checks each known one against
+// the final score. When some innings are unknown, the known innings only have
+// to not add up to more than the score.
+func (rg RetrosheetGame) LineScores() (visiting, home LineScore, err error) {
+	visiting, err = ParseLineScore(rg.VisitingLineScore)
+	if err != nil {
+		return LineScore{}, LineScore{}, err
+	}
+	home, err = ParseLineScore(rg.HomeLineScore)
+	if err != nil {
+		return LineScore{}, LineScore{}, err
+	}
+	sides := []struct {
+		team      string
+		lineScore LineScore
+		raw       string
+		score     int
+	}{
+		{rg.VisitingTeam, visiting, rg.VisitingLineScore, rg.VisitingScore},
+		{rg.HomeTeam, home, rg.HomeLineScore, rg.HomeScore},
+	}
+	for _, side := range sides {
+		if side.lineScore.Unknown || side.lineScore.Total() == side.score {
 			continue
 		}
-		if lookingForEnd {
-			stringSoFar += string(r)
+		if !side.lineScore.Complete() && side.lineScore.Total() <= side.score {
 			continue
 		}
-		score, err := strconv.Atoi(string(r))
-		if err != nil {
-			return nil, err
+		return visiting, home, &LineScoreMismatchError{
+			Date:          rg.Date,
+			VisitingTeam:  rg.VisitingTeam,
+			HomeTeam:      rg.HomeTeam,
+			Team:          side.team,
+			LineScore:     side.raw,
+			LineScoreRuns: side.lineScore.Total(),
+			Score:         side.score,
 		}
-		scores = append(scores, score)
 	}
-	return scores, nil
+	return visiting, home, nil
 }
 
 type ByTeamsBySeason struct {
@@ -180,13 +593,59 @@ func (btbs *ByTeamsBySeason) SortGames() {
 	for _, seasonMap := range btbs.m {
 		for _, season := range seasonMap {
 			sort.Slice(season.Games, func(i, j int) bool {
-				return season.Games[i].TeamGameNumber < season.Games[j].TeamGameNumber
+				return teamGameLess(season.Games[i], season.Games[j])
 			})
 		}
 	}
 	btbs.mu.Unlock()
 }
 
+// teamGameLess orders a team's games by its game number. Retrosheet's
+// doubleheader game number only orders games at one park on one day, so it is
+// used with the date to break ties when game numbers are missing or repeated.
+func teamGameLess(a, b TeamGame) bool {
+	if a.TeamGameNumber != b.TeamGameNumber {
+		return a.TeamGameNumber < b.TeamGameNumber
+	}
+	if !a.Date.Equal(b.Date) {
+		return a.Date.Before(b.Date)
+	}
+	return a.GameNumber < b.GameNumber
+}
+
+// SeasonsInYear returns every franchise's season for the given year, ordered
+// by franchise.
+func (btbs *ByTeamsBySeason) SeasonsInYear(year int) []*Season {
+	var seasons []*Season
+	btbs.mu.Lock()
+	for _, seasonMap := range btbs.m {
+		if season, ok := seasonMap[year]; ok {
+			seasons = append(seasons, season)
+		}
+	}
+	btbs.mu.Unlock()
+	sort.Slice(seasons, func(i, j int) bool { return seasons[i].Franchise < seasons[j].Franchise })
+	return seasons
+}
+
+// Years returns every year with at least one game, in order.
+func (btbs *ByTeamsBySeason) Years() []int {
+	seen := make(map[int]struct{})
+	btbs.mu.Lock()
+	for _, seasonMap := range btbs.m {
+		for year := range seasonMap {
+			seen[year] = struct{}{}
+		}
+	}
+	btbs.mu.Unlock()
+	years := make([]int, 0, len(seen))
+	for year := range seen {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	return years
+}
+
 func (btbs *ByTeamsBySeason) BySortedSeason() map[string][]*Season {
 	m := make(map[string][]*Season)
 	btbs.mu.Lock()
@@ -210,8 +669,16 @@ type Season struct {
 }
 
 func (s Season) GetSeasonRecord() SeasonRecord {
+	return s.RecordWhere(func(TeamGame) bool { return true })
+}
+
+// RecordWhere returns the season's record in the games keep accepts.
+func (s Season) RecordWhere(keep func(TeamGame) bool) SeasonRecord {
 	var sr SeasonRecord
 	for _, game := range s.Games {
+		if !keep(game) {
+			continue
+		}
 		if game.Result == Win {
 			sr.Wins++
 		} else if game.Result == Tie {
@@ -225,6 +692,43 @@ func (s Season) GetSeasonRecord() SeasonRecord {
 	return sr
 }
 
+// PersonRecord is a team's record in the games someone took part in.
+type PersonRecord struct {
+	Person Person
+	Games  int
+	Record SeasonRecord
+}
+
+// recordsBy splits the season's record by the person who picks out of each
+// game, most games first. Games where the person is unknown are left out.
+func (s Season) recordsBy(who func(TeamGame) Person) []PersonRecord {
+	index := make(map[string]*PersonRecord)
+	for _, game := range s.Games {
+		p := who(game)
+		if p.ID == "" {
+			continue
+		}
+		pr, ok := index[p.ID]
+		if !ok {
+			pr = &PersonRecord{Person: p}
+			index[p.ID] = pr
+		}
+		pr.Games++
+		addResult(&pr.Record, game.Result)
+	}
+	records := make([]PersonRecord, 0, len(index))
+	for _, pr := range index {
+		records = append(records, *pr)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Games != records[j].Games {
+			return records[i].Games > records[j].Games
+		}
+		return records[i].Person.ID < records[j].Person.ID
+	})
+	return records
+}
+
 type SeasonRecord struct {
 	Wins, Ties, Losses int
 }
@@ -238,33 +742,60 @@ func (sr SeasonRecord) String() string {
 
 func retroGameToTeamGame(rg *RetrosheetGame, franchiseConverter FranchiseConverter, isHome bool) TeamGame {
 	tg := TeamGame{
-		Date:        rg.Date,
-		ForfeitInfo: rg.ForfeitInfo,
+		GameID:       rg.GameID(),
+		Date:         rg.Date,
+		GameNumber:   rg.GameNumber,
+		IsHome:       isHome,
+		DayNight:     rg.DayNight,
+		LengthInOuts: rg.LengthInOuts,
+		Forfeit:      rg.Forfeit,
+		Protest:      rg.Protest,
+		ResultDate:   rg.ResultDate(),
 	}
 	if isHome {
 		tg.Team = rg.HomeTeam
 		tg.Franchise = franchiseConverter.Convert(rg.HomeTeam)
+		tg.League = rg.HomeLeague
 		tg.OpponentTeam = rg.VisitingTeam
 		tg.OpponentFranchise = franchiseConverter.Convert(rg.VisitingTeam)
+		tg.OpponentLeague = rg.VisitingLeague
 		tg.OpponentGameNumber = rg.VisitingGameNumber
 		tg.OpponentLineScore = rg.VisitingLineScore
 		tg.OpponentScore = rg.VisitingScore
 		tg.TeamGameNumber = rg.HomeGameNumber
+		tg.Manager = rg.HomeManager
+		tg.OpponentManager = rg.VisitingManager
+		tg.StartingPitcher = rg.HomeStartingPitcher
+		tg.OpponentStartingPitcher = rg.VisitingStartingPitcher
+		tg.Lineup = rg.HomeLineup
+		tg.OpponentLineup = rg.VisitingLineup
+		tg.Stats = rg.HomeStats
+		tg.OpponentStats = rg.VisitingStats
 		tg.TeamLineScore = rg.HomeLineScore
 		tg.TeamScore = rg.HomeScore
-		tg.Result = rg.GetHomeResult()
+		tg.Result = rg.HomeResult(resultMode)
 	} else {
 		tg.Team = rg.VisitingTeam
 		tg.Franchise = franchiseConverter.Convert(rg.VisitingTeam)
+		tg.League = rg.VisitingLeague
 		tg.OpponentTeam = rg.HomeTeam
 		tg.OpponentFranchise = franchiseConverter.Convert(rg.HomeTeam)
+		tg.OpponentLeague = rg.HomeLeague
 		tg.OpponentGameNumber = rg.HomeGameNumber
 		tg.OpponentLineScore = rg.HomeLineScore
 		tg.OpponentScore = rg.HomeScore
 		tg.TeamGameNumber = rg.VisitingGameNumber
+		tg.Manager = rg.VisitingManager
+		tg.OpponentManager = rg.HomeManager
+		tg.StartingPitcher = rg.VisitingStartingPitcher
+		tg.OpponentStartingPitcher = rg.HomeStartingPitcher
+		tg.Lineup = rg.VisitingLineup
+		tg.OpponentLineup = rg.HomeLineup
+		tg.Stats = rg.VisitingStats
+		tg.OpponentStats = rg.HomeStats
 		tg.TeamLineScore = rg.VisitingLineScore
 		tg.TeamScore = rg.VisitingScore
-		tg.Result = rg.GetVisitorResult()
+		tg.Result = rg.VisitorResult(resultMode)
 	}
 	return tg
 }
@@ -348,6 +879,10 @@ func ByRetrosheetGame(dir string, gameFunc func(*RetrosheetGame) error) error {
 }
 
 func processRetrosheetGame(record []string) (*RetrosheetGame, error) {
+	gameNumber, err := strconv.Atoi(record[1])
+	if err != nil {
+		return nil, err
+	}
 	visitingGameNumber, err := strconv.Atoi(record[5])
 	if err != nil {
 		return nil, err
@@ -368,17 +903,141 @@ func processRetrosheetGame(record []string) (*RetrosheetGame, error) {
 	if err != nil {
 		return nil, err
 	}
+	lengthInOuts, err := optionalInt(record[11])
+	if err != nil {
+		return nil, err
+	}
+	attendance, err := optionalInt(record[17])
+	if err != nil {
+		return nil, err
+	}
+	if attendance < 0 {
+		attendance = 0
+	}
+	durationMinutes, err := optionalInt(record[18])
+	if err != nil {
+		return nil, err
+	}
+	completion, err := parseCompletion(record[13])
+	if err != nil {
+		return nil, err
+	}
+	visitingStats, err := parseTeamStats(record[21:49])
+	if err != nil {
+		return nil, err
+	}
+	homeStats, err := parseTeamStats(record[49:77])
+	if err != nil {
+		return nil, err
+	}
+	visitingLineup, err := parseLineup(record[105:132])
+	if err != nil {
+		return nil, err
+	}
+	homeLineup, err := parseLineup(record[132:159])
+	if err != nil {
+		return nil, err
+	}
 
 	return &RetrosheetGame{
-		Date:               date,
-		VisitingTeam:       record[3],
-		VisitingGameNumber: visitingGameNumber,
-		HomeTeam:           record[6],
-		HomeGameNumber:     homeGameNumber,
-		VisitingScore:      visitingScore,
-		HomeScore:          homeScore,
-		ForfeitInfo:        record[14],
-		VisitingLineScore:  record[19],
-		HomeLineScore:      record[20],
+		Date:                    date,
+		GameNumber:              gameNumber,
+		VisitingTeam:            record[3],
+		VisitingLeague:          record[4],
+		VisitingGameNumber:      visitingGameNumber,
+		HomeTeam:                record[6],
+		HomeLeague:              record[7],
+		HomeGameNumber:          homeGameNumber,
+		VisitingScore:           visitingScore,
+		HomeScore:               homeScore,
+		DayNight:                DayNight(record[12]),
+		LengthInOuts:            lengthInOuts,
+		ParkID:                  record[16],
+		Attendance:              attendance,
+		DurationMinutes:         durationMinutes,
+		Completion:              completion,
+		Forfeit:                 Forfeit(record[14]),
+		Protest:                 Protest(record[15]),
+		VisitingLineScore:       record[19],
+		HomeLineScore:           record[20],
+		VisitingStats:           visitingStats,
+		HomeStats:               homeStats,
+		VisitingManager:         person(record[89], record[90]),
+		HomeManager:             person(record[91], record[92]),
+		WinningPitcher:          person(record[93], record[94]),
+		LosingPitcher:           person(record[95], record[96]),
+		SavingPitcher:           person(record[97], record[98]),
+		VisitingStartingPitcher: person(record[101], record[102]),
+		HomeStartingPitcher:     person(record[103], record[104]),
+		Umpires: Umpires{
+			HomePlate:  person(record[77], record[78]),
+			FirstBase:  person(record[79], record[80]),
+			SecondBase: person(record[81], record[82]),
+			ThirdBase:  person(record[83], record[84]),
+			LeftField:  person(record[85], record[86]),
+			RightField: person(record[87], record[88]),
+		},
+		VisitingLineup: visitingLineup,
+		HomeLineup:     homeLineup,
 	}, nil
 }
+
+// optionalInt parses a numeric field Retrosheet may leave blank, which is
+// read as 0.
+func optionalInt(field string) (int, error) {
+	if field == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(field)
+}
+
+// parseTeamStats reads a team's 28 field box score block: 17 offensive
+// fields, then 5 pitching and 6 defensive, in the order of TeamStats.
+func parseTeamStats(block []string) (TeamStats, error) {
+	var ts TeamStats
+	for i, n := range ts.fields() {
+		var err error
+		if *n, err = optionalInt(block[i]); err != nil {
+			return TeamStats{}, err
+		}
+	}
+	return ts, nil
+}
+
+// parseLineup reads a team's 27 starting lineup fields, an ID, name and
+// position for each batter in order.
+func parseLineup(block []string) (Lineup, error) {
+	var lineup Lineup
+	for i := range lineup {
+		position, err := optionalInt(block[i*3+2])
+		if err != nil {
+			return Lineup{}, err
+		}
+		lineup[i] = LineupSlot{Player: person(block[i*3], block[i*3+1]), Position: position}
+	}
+	return lineup, nil
+}
+
+// parseCompletion parses Retrosheet's completion field, "yyyymmdd,park,
+// visiting score,home score,outs", where the scores and outs are as of the
+// suspension. Old games leave the park blank.
+func parseCompletion(field string) (*Completion, error) {
+	if field == "" {
+		return nil, nil
+	}
+	parts := strings.Split(field, ",")
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("completion info %q: expected 5 fields", field)
+	}
+	date, err := time.Parse("20060102", parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("completion info %q: %w", field, err)
+	}
+	completion := &Completion{Date: date, Park: parts[1]}
+	for i, n := range []*int{&completion.VisitingScore, &completion.HomeScore, &completion.Outs} {
+		if *n, err = optionalInt(parts[i+2]); err != nil {
+			return nil, fmt.Errorf("completion info %q: %w", field, err)
+		}
+	}
+	return completion, nil
+}
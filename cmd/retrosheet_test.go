@@ -8,6 +8,99 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// The starting lineups in the test game logs, by the starting pitcher they
+// backed.
+var (
+	boydm001Lineup = Lineup{
+		{Player: Person{ID: "goodn002", Name: "Niko Goodrum"}, Position: 6},
+		{Player: Person{ID: "schoj001", Name: "Jonathan Schoop"}, Position: 4},
+		{Player: Person{ID: "cabrm001", Name: "Miguel Cabrera"}, Position: 10},
+		{Player: Person{ID: "cronc002", Name: "C.J. Cron"}, Position: 3},
+		{Player: Person{ID: "stewc002", Name: "Christin Stewart"}, Position: 7},
+		{Player: Person{ID: "candj002", Name: "Jeimer Candelario"}, Position: 5},
+		{Player: Person{ID: "maybc001", Name: "Cameron Maybin"}, Position: 9},
+		{Player: Person{ID: "romia002", Name: "Austin Romine"}, Position: 2},
+		{Player: Person{ID: "jonej006", Name: "JaCoby Jones"}, Position: 8},
+	}
+	coleg001Lineup = Lineup{
+		{Player: Person{ID: "hicka001", Name: "Aaron Hicks"}, Position: 8},
+		{Player: Person{ID: "judga001", Name: "Aaron Judge"}, Position: 9},
+		{Player: Person{ID: "torrg001", Name: "Gleyber Torres"}, Position: 6},
+		{Player: Person{ID: "stanm004", Name: "Giancarlo Stanton"}, Position: 10},
+		{Player: Person{ID: "gardb001", Name: "Brett Gardner"}, Position: 7},
+		{Player: Person{ID: "sancg002", Name: "Gary Sanchez"}, Position: 2},
+		{Player: Person{ID: "voitl001", Name: "Luke Voit"}, Position: 3},
+		{Player: Person{ID: "urshg001", Name: "Giovanny Urshela"}, Position: 5},
+		{Player: Person{ID: "wadet002", Name: "Tyler Wade"}, Position: 4},
+	}
+	cuetj001Lineup = Lineup{
+		{Player: Person{ID: "yastm001", Name: "Mike Yastrzemski"}, Position: 8},
+		{Player: Person{ID: "florw001", Name: "Wilmer Flores"}, Position: 5},
+		{Player: Person{ID: "sandp001", Name: "Pablo Sandoval"}, Position: 3},
+		{Player: Person{ID: "dicka001", Name: "Alex Dickerson"}, Position: 7},
+		{Player: Person{ID: "pench001", Name: "Hunter Pence"}, Position: 10},
+		{Player: Person{ID: "mccaj002", Name: "Joe McCarthy"}, Position: 9},
+		{Player: Person{ID: "dubom001", Name: "Mauricio Dubon"}, Position: 4},
+		{Player: Person{ID: "crawb001", Name: "Brandon Crawford"}, Position: 6},
+		{Player: Person{ID: "heint001", Name: "Tyler Heineman"}, Position: 2},
+	}
+	grays001Lineup = Lineup{
+		{Player: Person{ID: "ervip001", Name: "Phillip Ervin"}, Position: 7},
+		{Player: Person{ID: "vottj001", Name: "Joey Votto"}, Position: 3},
+		{Player: Person{ID: "suare001", Name: "Eugenio Suarez"}, Position: 5},
+		{Player: Person{ID: "castn001", Name: "Nick Castellanos"}, Position: 9},
+		{Player: Person{ID: "mousm001", Name: "Mike Moustakas"}, Position: 4},
+		{Player: Person{ID: "davim005", Name: "Matt Davidson"}, Position: 10},
+		{Player: Person{ID: "senzn001", Name: "Nick Senzel"}, Position: 8},
+		{Player: Person{ID: "galvf001", Name: "Freddy Galvis"}, Position: 6},
+		{Player: Person{ID: "casac001", Name: "Curt Casali"}, Position: 2},
+	}
+	hendk001Lineup = Lineup{
+		{Player: Person{ID: "bryak001", Name: "Kris Bryant"}, Position: 5},
+		{Player: Person{ID: "rizza001", Name: "Anthony Rizzo"}, Position: 3},
+		{Player: Person{ID: "baezj001", Name: "Javier Baez"}, Position: 6},
+		{Player: Person{ID: "schwk001", Name: "Kyle Schwarber"}, Position: 7},
+		{Player: Person{ID: "contw001", Name: "Willson Contreras"}, Position: 2},
+		{Player: Person{ID: "heywj001", Name: "Jason Heyward"}, Position: 9},
+		{Player: Person{ID: "carav001", Name: "Victor Caratini"}, Position: 10},
+		{Player: Person{ID: "hoern001", Name: "Nico Hoerner"}, Position: 4},
+		{Player: Person{ID: "happi001", Name: "Ian Happ"}, Position: 8},
+	}
+	mayd003Lineup = Lineup{
+		{Player: Person{ID: "muncm001", Name: "Max Muncy"}, Position: 3},
+		{Player: Person{ID: "bettm001", Name: "Mookie Betts"}, Position: 9},
+		{Player: Person{ID: "bellc002", Name: "Cody Bellinger"}, Position: 8},
+		{Player: Person{ID: "turnj001", Name: "Justin Turner"}, Position: 5},
+		{Player: Person{ID: "seagc001", Name: "Corey Seager"}, Position: 6},
+		{Player: Person{ID: "herne001", Name: "Enrique Hernandez"}, Position: 4},
+		{Player: Person{ID: "pedej001", Name: "Joc Pederson"}, Position: 7},
+		{Player: Person{ID: "polla001", Name: "A.J. Pollock"}, Position: 10},
+		{Player: Person{ID: "barna001", Name: "Austin Barnes"}, Position: 2},
+	}
+	schem001Lineup = Lineup{
+		{Player: Person{ID: "turnt001", Name: "Trea Turner"}, Position: 6},
+		{Player: Person{ID: "eatoa002", Name: "Adam Eaton"}, Position: 9},
+		{Player: Person{ID: "casts001", Name: "Starlin Castro"}, Position: 4},
+		{Player: Person{ID: "kendh001", Name: "Howie Kendrick"}, Position: 10},
+		{Player: Person{ID: "thame001", Name: "Eric Thames"}, Position: 3},
+		{Player: Person{ID: "suzuk001", Name: "Kurt Suzuki"}, Position: 2},
+		{Player: Person{ID: "cabra002", Name: "Asdrubal Cabrera"}, Position: 5},
+		{Player: Person{ID: "steva001", Name: "Andrew Stevenson"}, Position: 7},
+		{Player: Person{ID: "roblv001", Name: "Victor Robles"}, Position: 8},
+	}
+	woodb005Lineup = Lineup{
+		{Player: Person{ID: "sogae001", Name: "Eric Sogard"}, Position: 5},
+		{Player: Person{ID: "yelic001", Name: "Christian Yelich"}, Position: 7},
+		{Player: Person{ID: "hiurk001", Name: "Keston Hiura"}, Position: 4},
+		{Player: Person{ID: "smoaj001", Name: "Justin Smoak"}, Position: 3},
+		{Player: Person{ID: "braur002", Name: "Ryan Braun"}, Position: 10},
+		{Player: Person{ID: "garca003", Name: "Avisail Garcia"}, Position: 9},
+		{Player: Person{ID: "narvo001", Name: "Omar Narvaez"}, Position: 2},
+		{Player: Person{ID: "cainl001", Name: "Lorenzo Cain"}, Position: 8},
+		{Player: Person{ID: "arcio002", Name: "Orlando Arcia"}, Position: 6},
+	}
+)
+
 func TestGetTeamsBySeason(t *testing.T) {
 	teamsBySeason, err := GetTeamsBySeason("./test_data")
 	require.NoError(t, err)
@@ -20,34 +113,66 @@ func TestGetTeamsBySeason(t *testing.T) {
 					Year:      2000,
 					Games: []TeamGame{
 						{
-							Date:               time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC),
-							Team:               "SFN",
-							Franchise:          "SFN",
-							OpponentTeam:       "LAN",
-							OpponentFranchise:  "LAN",
-							OpponentGameNumber: 1,
-							TeamGameNumber:     1,
-							OpponentScore:      8,
-							TeamScore:          1,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "00010052x",
-							TeamLineScore:      "001000000",
-							Result:             Loss,
+							GameID:                  "LAN200007231",
+							Date:                    time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC),
+							GameNumber:              1,
+							Team:                    "SFN",
+							Franchise:               "SFN",
+							League:                  "NL",
+							OpponentTeam:            "LAN",
+							OpponentFranchise:       "LAN",
+							OpponentLeague:          "NL",
+							OpponentGameNumber:      1,
+							TeamGameNumber:          1,
+							Manager:                 Person{ID: "kaplg001", Name: "Gabe Kapler"},
+							OpponentManager:         Person{ID: "robed001", Name: "Dave Roberts"},
+							StartingPitcher:         Person{ID: "cuetj001", Name: "Johnny Cueto"},
+							OpponentStartingPitcher: Person{ID: "may-d003", Name: "Dustin May"},
+							Lineup:                  cuetj001Lineup,
+							OpponentLineup:          mayd003Lineup,
+							Stats:                   TeamStats{AtBats: 32, Hits: 8, RBI: 1, SacFlies: 1, Strikeouts: 8, GroundedIntoDoublePlays: 1, LeftOnBase: 5, PitchersUsed: 6, IndividualEarnedRuns: 8, TeamEarnedRuns: 8, Putouts: 24, Assists: 12, Errors: 1, DoublePlays: 1},
+							OpponentStats:           TeamStats{AtBats: 37, Hits: 12, Doubles: 4, HomeRuns: 1, RBI: 8, HitByPitch: 1, Walks: 5, Strikeouts: 6, GroundedIntoDoublePlays: 1, LeftOnBase: 11, PitchersUsed: 5, IndividualEarnedRuns: 1, TeamEarnedRuns: 1, Putouts: 27, Assists: 11, Errors: 1, DoublePlays: 2},
+							IsHome:                  false,
+							DayNight:                Night,
+							LengthInOuts:            51,
+							OpponentScore:           8,
+							TeamScore:               1,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "00010052x",
+							TeamLineScore:           "001000000",
+							Result:                  Loss,
+							ResultDate:              time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC),
 						},
 						{
-							Date:               time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC),
-							Team:               "SFN",
-							Franchise:          "SFN",
-							OpponentTeam:       "LAN",
-							OpponentFranchise:  "LAN",
-							OpponentGameNumber: 2,
-							TeamGameNumber:     2,
-							OpponentScore:      1,
-							TeamScore:          4,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "10000x",
-							TeamLineScore:      "201010",
-							Result:             Win,
+							GameID:                  "LAN200007232",
+							Date:                    time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC),
+							GameNumber:              2,
+							Team:                    "SFN",
+							Franchise:               "SFN",
+							League:                  "AL",
+							OpponentTeam:            "LAN",
+							OpponentFranchise:       "LAN",
+							OpponentLeague:          "NL",
+							OpponentGameNumber:      2,
+							TeamGameNumber:          2,
+							Manager:                 Person{ID: "boona001", Name: "Aaron Boone"},
+							OpponentManager:         Person{ID: "martd002", Name: "Dave Martinez"},
+							StartingPitcher:         Person{ID: "coleg001", Name: "Gerrit Cole"},
+							OpponentStartingPitcher: Person{ID: "schem001", Name: "Max Scherzer"},
+							Lineup:                  coleg001Lineup,
+							OpponentLineup:          schem001Lineup,
+							Stats:                   TeamStats{AtBats: 22, Hits: 6, Doubles: 1, HomeRuns: 1, RBI: 4, Walks: 4, Strikeouts: 11, LeftOnBase: 6, PitchersUsed: 1, IndividualEarnedRuns: 1, TeamEarnedRuns: 1, Putouts: 15, Assists: 1},
+							OpponentStats:           TeamStats{AtBats: 16, Hits: 1, HomeRuns: 1, RBI: 1, HitByPitch: 1, Walks: 1, Strikeouts: 5, LeftOnBase: 2, PitchersUsed: 1, IndividualEarnedRuns: 4, TeamEarnedRuns: 4, Putouts: 16, Assists: 4},
+							IsHome:                  false,
+							DayNight:                Night,
+							LengthInOuts:            31,
+							OpponentScore:           1,
+							TeamScore:               4,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "10000x",
+							TeamLineScore:           "201010",
+							Result:                  Win,
+							ResultDate:              time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC),
 						},
 					},
 				},
@@ -57,34 +182,66 @@ func TestGetTeamsBySeason(t *testing.T) {
 					Year:      2001,
 					Games: []TeamGame{
 						{
-							Date:               time.Date(2001, time.July, 23, 0, 0, 0, 0, time.UTC),
-							Team:               "SFN",
-							Franchise:          "SFN",
-							OpponentTeam:       "BRO",
-							OpponentFranchise:  "LAN",
-							OpponentGameNumber: 1,
-							TeamGameNumber:     1,
-							OpponentScore:      5,
-							TeamScore:          3,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "00000050x",
-							TeamLineScore:      "001010100",
-							Result:             Loss,
+							GameID:                  "BRO200107231",
+							Date:                    time.Date(2001, time.July, 23, 0, 0, 0, 0, time.UTC),
+							GameNumber:              1,
+							Team:                    "SFN",
+							Franchise:               "SFN",
+							League:                  "NL",
+							OpponentTeam:            "BRO",
+							OpponentFranchise:       "LAN",
+							OpponentLeague:          "NL",
+							OpponentGameNumber:      1,
+							TeamGameNumber:          1,
+							Manager:                 Person{ID: "kaplg001", Name: "Gabe Kapler"},
+							OpponentManager:         Person{ID: "robed001", Name: "Dave Roberts"},
+							StartingPitcher:         Person{ID: "cuetj001", Name: "Johnny Cueto"},
+							OpponentStartingPitcher: Person{ID: "may-d003", Name: "Dustin May"},
+							Lineup:                  cuetj001Lineup,
+							OpponentLineup:          mayd003Lineup,
+							Stats:                   TeamStats{AtBats: 32, Hits: 8, RBI: 1, SacFlies: 1, Strikeouts: 8, GroundedIntoDoublePlays: 1, LeftOnBase: 5, PitchersUsed: 6, IndividualEarnedRuns: 8, TeamEarnedRuns: 8, Putouts: 24, Assists: 12, Errors: 1, DoublePlays: 1},
+							OpponentStats:           TeamStats{AtBats: 37, Hits: 12, Doubles: 4, HomeRuns: 1, RBI: 8, HitByPitch: 1, Walks: 5, Strikeouts: 6, GroundedIntoDoublePlays: 1, LeftOnBase: 11, PitchersUsed: 5, IndividualEarnedRuns: 1, TeamEarnedRuns: 1, Putouts: 27, Assists: 11, Errors: 1, DoublePlays: 2},
+							IsHome:                  false,
+							DayNight:                Night,
+							LengthInOuts:            51,
+							OpponentScore:           5,
+							TeamScore:               3,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "00000050x",
+							TeamLineScore:           "001010100",
+							Result:                  Loss,
+							ResultDate:              time.Date(2001, time.July, 23, 0, 0, 0, 0, time.UTC),
 						},
 						{
-							Date:               time.Date(2001, time.July, 23, 0, 0, 0, 0, time.UTC),
-							Team:               "SFN",
-							Franchise:          "SFN",
-							OpponentTeam:       "BRO",
-							OpponentFranchise:  "LAN",
-							OpponentGameNumber: 2,
-							TeamGameNumber:     2,
-							OpponentScore:      1,
-							TeamScore:          24,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "10000x",
-							TeamLineScore:      "(20)01012",
-							Result:             Win,
+							GameID:                  "BRO200107232",
+							Date:                    time.Date(2001, time.July, 23, 0, 0, 0, 0, time.UTC),
+							GameNumber:              2,
+							Team:                    "SFN",
+							Franchise:               "SFN",
+							League:                  "AL",
+							OpponentTeam:            "BRO",
+							OpponentFranchise:       "LAN",
+							OpponentLeague:          "NL",
+							OpponentGameNumber:      2,
+							TeamGameNumber:          2,
+							Manager:                 Person{ID: "boona001", Name: "Aaron Boone"},
+							OpponentManager:         Person{ID: "martd002", Name: "Dave Martinez"},
+							StartingPitcher:         Person{ID: "coleg001", Name: "Gerrit Cole"},
+							OpponentStartingPitcher: Person{ID: "schem001", Name: "Max Scherzer"},
+							Lineup:                  coleg001Lineup,
+							OpponentLineup:          schem001Lineup,
+							Stats:                   TeamStats{AtBats: 22, Hits: 6, Doubles: 1, HomeRuns: 1, RBI: 4, Walks: 4, Strikeouts: 11, LeftOnBase: 6, PitchersUsed: 1, IndividualEarnedRuns: 1, TeamEarnedRuns: 1, Putouts: 15, Assists: 1},
+							OpponentStats:           TeamStats{AtBats: 16, Hits: 1, HomeRuns: 1, RBI: 1, HitByPitch: 1, Walks: 1, Strikeouts: 5, LeftOnBase: 2, PitchersUsed: 1, IndividualEarnedRuns: 4, TeamEarnedRuns: 4, Putouts: 16, Assists: 4},
+							IsHome:                  false,
+							DayNight:                Night,
+							LengthInOuts:            31,
+							OpponentScore:           1,
+							TeamScore:               24,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "10000x",
+							TeamLineScore:           "(20)01012",
+							Result:                  Win,
+							ResultDate:              time.Date(2001, time.July, 23, 0, 0, 0, 0, time.UTC),
 						},
 					},
 				},
@@ -96,34 +253,66 @@ func TestGetTeamsBySeason(t *testing.T) {
 					Year:      2000,
 					Games: []TeamGame{
 						{
-							Date:               time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC),
-							Team:               "LAN",
-							Franchise:          "LAN",
-							OpponentTeam:       "SFN",
-							OpponentFranchise:  "SFN",
-							OpponentGameNumber: 1,
-							TeamGameNumber:     1,
-							OpponentScore:      1,
-							TeamScore:          8,
-							ForfeitInfo:        "",
-							TeamLineScore:      "00010052x",
-							OpponentLineScore:  "001000000",
-							Result:             Win,
+							GameID:                  "LAN200007231",
+							Date:                    time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC),
+							GameNumber:              1,
+							Team:                    "LAN",
+							Franchise:               "LAN",
+							League:                  "NL",
+							OpponentTeam:            "SFN",
+							OpponentFranchise:       "SFN",
+							OpponentLeague:          "NL",
+							OpponentGameNumber:      1,
+							TeamGameNumber:          1,
+							Manager:                 Person{ID: "robed001", Name: "Dave Roberts"},
+							OpponentManager:         Person{ID: "kaplg001", Name: "Gabe Kapler"},
+							StartingPitcher:         Person{ID: "may-d003", Name: "Dustin May"},
+							OpponentStartingPitcher: Person{ID: "cuetj001", Name: "Johnny Cueto"},
+							Lineup:                  mayd003Lineup,
+							OpponentLineup:          cuetj001Lineup,
+							Stats:                   TeamStats{AtBats: 37, Hits: 12, Doubles: 4, HomeRuns: 1, RBI: 8, HitByPitch: 1, Walks: 5, Strikeouts: 6, GroundedIntoDoublePlays: 1, LeftOnBase: 11, PitchersUsed: 5, IndividualEarnedRuns: 1, TeamEarnedRuns: 1, Putouts: 27, Assists: 11, Errors: 1, DoublePlays: 2},
+							OpponentStats:           TeamStats{AtBats: 32, Hits: 8, RBI: 1, SacFlies: 1, Strikeouts: 8, GroundedIntoDoublePlays: 1, LeftOnBase: 5, PitchersUsed: 6, IndividualEarnedRuns: 8, TeamEarnedRuns: 8, Putouts: 24, Assists: 12, Errors: 1, DoublePlays: 1},
+							IsHome:                  true,
+							DayNight:                Night,
+							LengthInOuts:            51,
+							OpponentScore:           1,
+							TeamScore:               8,
+							Forfeit:                 NoForfeit,
+							TeamLineScore:           "00010052x",
+							OpponentLineScore:       "001000000",
+							Result:                  Win,
+							ResultDate:              time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC),
 						},
 						{
-							Date:               time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC),
-							Team:               "LAN",
-							Franchise:          "LAN",
-							OpponentTeam:       "SFN",
-							OpponentFranchise:  "SFN",
-							OpponentGameNumber: 2,
-							TeamGameNumber:     2,
-							OpponentScore:      4,
-							TeamScore:          1,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "201010",
-							TeamLineScore:      "10000x",
-							Result:             Loss,
+							GameID:                  "LAN200007232",
+							Date:                    time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC),
+							GameNumber:              2,
+							Team:                    "LAN",
+							Franchise:               "LAN",
+							League:                  "NL",
+							OpponentTeam:            "SFN",
+							OpponentFranchise:       "SFN",
+							OpponentLeague:          "AL",
+							OpponentGameNumber:      2,
+							TeamGameNumber:          2,
+							Manager:                 Person{ID: "martd002", Name: "Dave Martinez"},
+							OpponentManager:         Person{ID: "boona001", Name: "Aaron Boone"},
+							StartingPitcher:         Person{ID: "schem001", Name: "Max Scherzer"},
+							OpponentStartingPitcher: Person{ID: "coleg001", Name: "Gerrit Cole"},
+							Lineup:                  schem001Lineup,
+							OpponentLineup:          coleg001Lineup,
+							Stats:                   TeamStats{AtBats: 16, Hits: 1, HomeRuns: 1, RBI: 1, HitByPitch: 1, Walks: 1, Strikeouts: 5, LeftOnBase: 2, PitchersUsed: 1, IndividualEarnedRuns: 4, TeamEarnedRuns: 4, Putouts: 16, Assists: 4},
+							OpponentStats:           TeamStats{AtBats: 22, Hits: 6, Doubles: 1, HomeRuns: 1, RBI: 4, Walks: 4, Strikeouts: 11, LeftOnBase: 6, PitchersUsed: 1, IndividualEarnedRuns: 1, TeamEarnedRuns: 1, Putouts: 15, Assists: 1},
+							IsHome:                  true,
+							DayNight:                Night,
+							LengthInOuts:            31,
+							OpponentScore:           4,
+							TeamScore:               1,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "201010",
+							TeamLineScore:           "10000x",
+							Result:                  Loss,
+							ResultDate:              time.Date(2000, time.July, 23, 0, 0, 0, 0, time.UTC),
 						},
 					},
 				},
@@ -133,34 +322,66 @@ func TestGetTeamsBySeason(t *testing.T) {
 					Year:      2001,
 					Games: []TeamGame{
 						{
-							Date:               time.Date(2001, time.July, 23, 0, 0, 0, 0, time.UTC),
-							Team:               "BRO",
-							Franchise:          "LAN",
-							OpponentTeam:       "SFN",
-							OpponentFranchise:  "SFN",
-							OpponentGameNumber: 1,
-							TeamGameNumber:     1,
-							OpponentScore:      3,
-							TeamScore:          5,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "001010100",
-							TeamLineScore:      "00000050x",
-							Result:             Win,
+							GameID:                  "BRO200107231",
+							Date:                    time.Date(2001, time.July, 23, 0, 0, 0, 0, time.UTC),
+							GameNumber:              1,
+							Team:                    "BRO",
+							Franchise:               "LAN",
+							League:                  "NL",
+							OpponentTeam:            "SFN",
+							OpponentFranchise:       "SFN",
+							OpponentLeague:          "NL",
+							OpponentGameNumber:      1,
+							TeamGameNumber:          1,
+							Manager:                 Person{ID: "robed001", Name: "Dave Roberts"},
+							OpponentManager:         Person{ID: "kaplg001", Name: "Gabe Kapler"},
+							StartingPitcher:         Person{ID: "may-d003", Name: "Dustin May"},
+							OpponentStartingPitcher: Person{ID: "cuetj001", Name: "Johnny Cueto"},
+							Lineup:                  mayd003Lineup,
+							OpponentLineup:          cuetj001Lineup,
+							Stats:                   TeamStats{AtBats: 37, Hits: 12, Doubles: 4, HomeRuns: 1, RBI: 8, HitByPitch: 1, Walks: 5, Strikeouts: 6, GroundedIntoDoublePlays: 1, LeftOnBase: 11, PitchersUsed: 5, IndividualEarnedRuns: 1, TeamEarnedRuns: 1, Putouts: 27, Assists: 11, Errors: 1, DoublePlays: 2},
+							OpponentStats:           TeamStats{AtBats: 32, Hits: 8, RBI: 1, SacFlies: 1, Strikeouts: 8, GroundedIntoDoublePlays: 1, LeftOnBase: 5, PitchersUsed: 6, IndividualEarnedRuns: 8, TeamEarnedRuns: 8, Putouts: 24, Assists: 12, Errors: 1, DoublePlays: 1},
+							IsHome:                  true,
+							DayNight:                Night,
+							LengthInOuts:            51,
+							OpponentScore:           3,
+							TeamScore:               5,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "001010100",
+							TeamLineScore:           "00000050x",
+							Result:                  Win,
+							ResultDate:              time.Date(2001, time.July, 23, 0, 0, 0, 0, time.UTC),
 						},
 						{
-							Date:               time.Date(2001, time.July, 23, 0, 0, 0, 0, time.UTC),
-							Team:               "BRO",
-							Franchise:          "LAN",
-							OpponentTeam:       "SFN",
-							OpponentFranchise:  "SFN",
-							OpponentGameNumber: 2,
-							TeamGameNumber:     2,
-							OpponentScore:      24,
-							TeamScore:          1,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "(20)01012",
-							TeamLineScore:      "10000x",
-							Result:             Loss,
+							GameID:                  "BRO200107232",
+							Date:                    time.Date(2001, time.July, 23, 0, 0, 0, 0, time.UTC),
+							GameNumber:              2,
+							Team:                    "BRO",
+							Franchise:               "LAN",
+							League:                  "NL",
+							OpponentTeam:            "SFN",
+							OpponentFranchise:       "SFN",
+							OpponentLeague:          "AL",
+							OpponentGameNumber:      2,
+							TeamGameNumber:          2,
+							Manager:                 Person{ID: "martd002", Name: "Dave Martinez"},
+							OpponentManager:         Person{ID: "boona001", Name: "Aaron Boone"},
+							StartingPitcher:         Person{ID: "schem001", Name: "Max Scherzer"},
+							OpponentStartingPitcher: Person{ID: "coleg001", Name: "Gerrit Cole"},
+							Lineup:                  schem001Lineup,
+							OpponentLineup:          coleg001Lineup,
+							Stats:                   TeamStats{AtBats: 16, Hits: 1, HomeRuns: 1, RBI: 1, HitByPitch: 1, Walks: 1, Strikeouts: 5, LeftOnBase: 2, PitchersUsed: 1, IndividualEarnedRuns: 4, TeamEarnedRuns: 4, Putouts: 16, Assists: 4},
+							OpponentStats:           TeamStats{AtBats: 22, Hits: 6, Doubles: 1, HomeRuns: 1, RBI: 4, Walks: 4, Strikeouts: 11, LeftOnBase: 6, PitchersUsed: 1, IndividualEarnedRuns: 1, TeamEarnedRuns: 1, Putouts: 15, Assists: 1},
+							IsHome:                  true,
+							DayNight:                Night,
+							LengthInOuts:            31,
+							OpponentScore:           24,
+							TeamScore:               1,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "(20)01012",
+							TeamLineScore:           "10000x",
+							Result:                  Loss,
+							ResultDate:              time.Date(2001, time.July, 23, 0, 0, 0, 0, time.UTC),
 						},
 					},
 				},
@@ -172,34 +393,66 @@ func TestGetTeamsBySeason(t *testing.T) {
 					Year:      2000,
 					Games: []TeamGame{
 						{
-							Date:               time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
-							Team:               "MIL",
-							Franchise:          "MIL",
-							OpponentTeam:       "CHN",
-							OpponentFranchise:  "CHN",
-							OpponentGameNumber: 1,
-							TeamGameNumber:     1,
-							OpponentScore:      3,
-							TeamScore:          0,
-							ForfeitInfo:        "",
-							TeamLineScore:      "000000000",
-							OpponentLineScore:  "00200001x",
-							Result:             Loss,
+							GameID:                  "CHN200007241",
+							Date:                    time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
+							GameNumber:              1,
+							Team:                    "MIL",
+							Franchise:               "MIL",
+							League:                  "NL",
+							OpponentTeam:            "CHN",
+							OpponentFranchise:       "CHN",
+							OpponentLeague:          "NL",
+							OpponentGameNumber:      1,
+							TeamGameNumber:          1,
+							Manager:                 Person{ID: "counc001", Name: "Craig Counsell"},
+							OpponentManager:         Person{ID: "rossd001", Name: "David Ross"},
+							StartingPitcher:         Person{ID: "woodb005", Name: "Brandon Woodruff"},
+							OpponentStartingPitcher: Person{ID: "hendk001", Name: "Kyle Hendricks"},
+							Lineup:                  woodb005Lineup,
+							OpponentLineup:          hendk001Lineup,
+							Stats:                   TeamStats{AtBats: 30, Hits: 3, Strikeouts: 9, LeftOnBase: 3, PitchersUsed: 5, IndividualEarnedRuns: 3, TeamEarnedRuns: 3, Putouts: 24, Assists: 11, Errors: 1, DoublePlays: 2},
+							OpponentStats:           TeamStats{AtBats: 28, Hits: 5, Doubles: 1, HomeRuns: 2, RBI: 3, HitByPitch: 1, Walks: 1, Strikeouts: 7, GroundedIntoDoublePlays: 2, LeftOnBase: 3, PitchersUsed: 1, Putouts: 27, Assists: 12},
+							IsHome:                  false,
+							DayNight:                Night,
+							LengthInOuts:            51,
+							OpponentScore:           3,
+							TeamScore:               0,
+							Forfeit:                 NoForfeit,
+							TeamLineScore:           "000000000",
+							OpponentLineScore:       "00200001x",
+							Result:                  Loss,
+							ResultDate:              time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
 						},
 						{
-							Date:               time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
-							Team:               "MIL",
-							Franchise:          "MIL",
-							OpponentTeam:       "CHN",
-							OpponentFranchise:  "CHN",
-							OpponentGameNumber: 2,
-							TeamGameNumber:     2,
-							OpponentScore:      7,
-							TeamScore:          1,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "20101120x",
-							TeamLineScore:      "000100000",
-							Result:             Loss,
+							GameID:                  "CHN200007242",
+							Date:                    time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
+							GameNumber:              2,
+							Team:                    "MIL",
+							Franchise:               "MIL",
+							League:                  "AL",
+							OpponentTeam:            "CHN",
+							OpponentFranchise:       "CHN",
+							OpponentLeague:          "NL",
+							OpponentGameNumber:      2,
+							TeamGameNumber:          2,
+							Manager:                 Person{ID: "gardr001", Name: "Ron Gardenhire"},
+							OpponentManager:         Person{ID: "belld002", Name: "David Bell"},
+							StartingPitcher:         Person{ID: "boydm001", Name: "Matt Boyd"},
+							OpponentStartingPitcher: Person{ID: "grays001", Name: "Sonny Gray"},
+							Lineup:                  boydm001Lineup,
+							OpponentLineup:          grays001Lineup,
+							Stats:                   TeamStats{AtBats: 28, Hits: 3, HomeRuns: 1, RBI: 1, Walks: 3, Strikeouts: 13, GroundedIntoDoublePlays: 2, LeftOnBase: 3, PitchersUsed: 5, IndividualEarnedRuns: 7, TeamEarnedRuns: 7, Putouts: 24, Assists: 9, DoublePlays: 2},
+							OpponentStats:           TeamStats{AtBats: 31, Hits: 9, Doubles: 1, HomeRuns: 2, RBI: 7, HitByPitch: 2, Walks: 4, Strikeouts: 4, StolenBases: 1, GroundedIntoDoublePlays: 1, LeftOnBase: 6, PitchersUsed: 4, IndividualEarnedRuns: 1, TeamEarnedRuns: 1, WildPitches: 1, Putouts: 27, Assists: 8, DoublePlays: 2},
+							IsHome:                  false,
+							DayNight:                Night,
+							LengthInOuts:            51,
+							OpponentScore:           7,
+							TeamScore:               1,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "20101120x",
+							TeamLineScore:           "000100000",
+							Result:                  Loss,
+							ResultDate:              time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
 						},
 					},
 				},
@@ -209,34 +462,66 @@ func TestGetTeamsBySeason(t *testing.T) {
 					Year:      2001,
 					Games: []TeamGame{
 						{
-							Date:               time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
-							Team:               "MIL",
-							Franchise:          "MIL",
-							OpponentTeam:       "CHN",
-							OpponentFranchise:  "CHN",
-							OpponentGameNumber: 1,
-							TeamGameNumber:     1,
-							TeamScore:          1,
-							OpponentScore:      3,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "00200001x",
-							TeamLineScore:      "001000000",
-							Result:             Loss,
+							GameID:                  "CHN200107241",
+							Date:                    time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
+							GameNumber:              1,
+							Team:                    "MIL",
+							Franchise:               "MIL",
+							League:                  "NL",
+							OpponentTeam:            "CHN",
+							OpponentFranchise:       "CHN",
+							OpponentLeague:          "NL",
+							OpponentGameNumber:      1,
+							TeamGameNumber:          1,
+							Manager:                 Person{ID: "counc001", Name: "Craig Counsell"},
+							OpponentManager:         Person{ID: "rossd001", Name: "David Ross"},
+							StartingPitcher:         Person{ID: "woodb005", Name: "Brandon Woodruff"},
+							OpponentStartingPitcher: Person{ID: "hendk001", Name: "Kyle Hendricks"},
+							Lineup:                  woodb005Lineup,
+							OpponentLineup:          hendk001Lineup,
+							Stats:                   TeamStats{AtBats: 30, Hits: 3, Strikeouts: 9, LeftOnBase: 3, PitchersUsed: 5, IndividualEarnedRuns: 3, TeamEarnedRuns: 3, Putouts: 24, Assists: 11, Errors: 1, DoublePlays: 2},
+							OpponentStats:           TeamStats{AtBats: 28, Hits: 5, Doubles: 1, HomeRuns: 2, RBI: 3, HitByPitch: 1, Walks: 1, Strikeouts: 7, GroundedIntoDoublePlays: 2, LeftOnBase: 3, PitchersUsed: 1, Putouts: 27, Assists: 12},
+							IsHome:                  false,
+							DayNight:                Night,
+							LengthInOuts:            51,
+							TeamScore:               1,
+							OpponentScore:           3,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "00200001x",
+							TeamLineScore:           "001000000",
+							Result:                  Loss,
+							ResultDate:              time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
 						},
 						{
-							Date:               time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
-							Team:               "MIL",
-							Franchise:          "MIL",
-							OpponentTeam:       "CHN",
-							OpponentFranchise:  "CHN",
-							OpponentGameNumber: 2,
-							TeamGameNumber:     2,
-							OpponentScore:      7,
-							TeamScore:          15,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "20101120x",
-							TeamLineScore:      "000(10)02030",
-							Result:             Win,
+							GameID:                  "CHN200107242",
+							Date:                    time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
+							GameNumber:              2,
+							Team:                    "MIL",
+							Franchise:               "MIL",
+							League:                  "AL",
+							OpponentTeam:            "CHN",
+							OpponentFranchise:       "CHN",
+							OpponentLeague:          "NL",
+							OpponentGameNumber:      2,
+							TeamGameNumber:          2,
+							Manager:                 Person{ID: "gardr001", Name: "Ron Gardenhire"},
+							OpponentManager:         Person{ID: "belld002", Name: "David Bell"},
+							StartingPitcher:         Person{ID: "boydm001", Name: "Matt Boyd"},
+							OpponentStartingPitcher: Person{ID: "grays001", Name: "Sonny Gray"},
+							Lineup:                  boydm001Lineup,
+							OpponentLineup:          grays001Lineup,
+							Stats:                   TeamStats{AtBats: 28, Hits: 3, HomeRuns: 1, RBI: 1, Walks: 3, Strikeouts: 13, GroundedIntoDoublePlays: 2, LeftOnBase: 3, PitchersUsed: 5, IndividualEarnedRuns: 7, TeamEarnedRuns: 7, Putouts: 24, Assists: 9, DoublePlays: 2},
+							OpponentStats:           TeamStats{AtBats: 31, Hits: 9, Doubles: 1, HomeRuns: 2, RBI: 7, HitByPitch: 2, Walks: 4, Strikeouts: 4, StolenBases: 1, GroundedIntoDoublePlays: 1, LeftOnBase: 6, PitchersUsed: 4, IndividualEarnedRuns: 1, TeamEarnedRuns: 1, WildPitches: 1, Putouts: 27, Assists: 8, DoublePlays: 2},
+							IsHome:                  false,
+							DayNight:                Night,
+							LengthInOuts:            51,
+							OpponentScore:           7,
+							TeamScore:               15,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "20101120x",
+							TeamLineScore:           "000(10)02030",
+							Result:                  Win,
+							ResultDate:              time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
 						},
 					},
 				},
@@ -248,34 +533,66 @@ func TestGetTeamsBySeason(t *testing.T) {
 					Year:      2000,
 					Games: []TeamGame{
 						{
-							Date:               time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
-							Team:               "CHN",
-							Franchise:          "CHN",
-							OpponentTeam:       "MIL",
-							OpponentFranchise:  "MIL",
-							OpponentGameNumber: 1,
-							TeamGameNumber:     1,
-							OpponentScore:      0,
-							TeamScore:          3,
-							ForfeitInfo:        "",
-							TeamLineScore:      "00200001x",
-							OpponentLineScore:  "000000000",
-							Result:             Win,
+							GameID:                  "CHN200007241",
+							Date:                    time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
+							GameNumber:              1,
+							Team:                    "CHN",
+							Franchise:               "CHN",
+							League:                  "NL",
+							OpponentTeam:            "MIL",
+							OpponentFranchise:       "MIL",
+							OpponentLeague:          "NL",
+							OpponentGameNumber:      1,
+							TeamGameNumber:          1,
+							Manager:                 Person{ID: "rossd001", Name: "David Ross"},
+							OpponentManager:         Person{ID: "counc001", Name: "Craig Counsell"},
+							StartingPitcher:         Person{ID: "hendk001", Name: "Kyle Hendricks"},
+							OpponentStartingPitcher: Person{ID: "woodb005", Name: "Brandon Woodruff"},
+							Lineup:                  hendk001Lineup,
+							OpponentLineup:          woodb005Lineup,
+							Stats:                   TeamStats{AtBats: 28, Hits: 5, Doubles: 1, HomeRuns: 2, RBI: 3, HitByPitch: 1, Walks: 1, Strikeouts: 7, GroundedIntoDoublePlays: 2, LeftOnBase: 3, PitchersUsed: 1, Putouts: 27, Assists: 12},
+							OpponentStats:           TeamStats{AtBats: 30, Hits: 3, Strikeouts: 9, LeftOnBase: 3, PitchersUsed: 5, IndividualEarnedRuns: 3, TeamEarnedRuns: 3, Putouts: 24, Assists: 11, Errors: 1, DoublePlays: 2},
+							IsHome:                  true,
+							DayNight:                Night,
+							LengthInOuts:            51,
+							OpponentScore:           0,
+							TeamScore:               3,
+							Forfeit:                 NoForfeit,
+							TeamLineScore:           "00200001x",
+							OpponentLineScore:       "000000000",
+							Result:                  Win,
+							ResultDate:              time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
 						},
 						{
-							Date:               time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
-							Team:               "CHN",
-							Franchise:          "CHN",
-							OpponentTeam:       "MIL",
-							OpponentFranchise:  "MIL",
-							OpponentGameNumber: 2,
-							TeamGameNumber:     2,
-							OpponentScore:      1,
-							TeamScore:          7,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "000100000",
-							TeamLineScore:      "20101120x",
-							Result:             Win,
+							GameID:                  "CHN200007242",
+							Date:                    time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
+							GameNumber:              2,
+							Team:                    "CHN",
+							Franchise:               "CHN",
+							League:                  "NL",
+							OpponentTeam:            "MIL",
+							OpponentFranchise:       "MIL",
+							OpponentLeague:          "AL",
+							OpponentGameNumber:      2,
+							TeamGameNumber:          2,
+							Manager:                 Person{ID: "belld002", Name: "David Bell"},
+							OpponentManager:         Person{ID: "gardr001", Name: "Ron Gardenhire"},
+							StartingPitcher:         Person{ID: "grays001", Name: "Sonny Gray"},
+							OpponentStartingPitcher: Person{ID: "boydm001", Name: "Matt Boyd"},
+							Lineup:                  grays001Lineup,
+							OpponentLineup:          boydm001Lineup,
+							Stats:                   TeamStats{AtBats: 31, Hits: 9, Doubles: 1, HomeRuns: 2, RBI: 7, HitByPitch: 2, Walks: 4, Strikeouts: 4, StolenBases: 1, GroundedIntoDoublePlays: 1, LeftOnBase: 6, PitchersUsed: 4, IndividualEarnedRuns: 1, TeamEarnedRuns: 1, WildPitches: 1, Putouts: 27, Assists: 8, DoublePlays: 2},
+							OpponentStats:           TeamStats{AtBats: 28, Hits: 3, HomeRuns: 1, RBI: 1, Walks: 3, Strikeouts: 13, GroundedIntoDoublePlays: 2, LeftOnBase: 3, PitchersUsed: 5, IndividualEarnedRuns: 7, TeamEarnedRuns: 7, Putouts: 24, Assists: 9, DoublePlays: 2},
+							IsHome:                  true,
+							DayNight:                Night,
+							LengthInOuts:            51,
+							OpponentScore:           1,
+							TeamScore:               7,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "000100000",
+							TeamLineScore:           "20101120x",
+							Result:                  Win,
+							ResultDate:              time.Date(2000, time.July, 24, 0, 0, 0, 0, time.UTC),
 						},
 					},
 				},
@@ -285,34 +602,66 @@ func TestGetTeamsBySeason(t *testing.T) {
 					Year:      2001,
 					Games: []TeamGame{
 						{
-							Date:               time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
-							Team:               "CHN",
-							Franchise:          "CHN",
-							OpponentTeam:       "MIL",
-							OpponentFranchise:  "MIL",
-							OpponentGameNumber: 1,
-							TeamGameNumber:     1,
-							TeamScore:          3,
-							OpponentScore:      1,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "001000000",
-							TeamLineScore:      "00200001x",
-							Result:             Win,
+							GameID:                  "CHN200107241",
+							Date:                    time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
+							GameNumber:              1,
+							Team:                    "CHN",
+							Franchise:               "CHN",
+							League:                  "NL",
+							OpponentTeam:            "MIL",
+							OpponentFranchise:       "MIL",
+							OpponentLeague:          "NL",
+							OpponentGameNumber:      1,
+							TeamGameNumber:          1,
+							Manager:                 Person{ID: "rossd001", Name: "David Ross"},
+							OpponentManager:         Person{ID: "counc001", Name: "Craig Counsell"},
+							StartingPitcher:         Person{ID: "hendk001", Name: "Kyle Hendricks"},
+							OpponentStartingPitcher: Person{ID: "woodb005", Name: "Brandon Woodruff"},
+							Lineup:                  hendk001Lineup,
+							OpponentLineup:          woodb005Lineup,
+							Stats:                   TeamStats{AtBats: 28, Hits: 5, Doubles: 1, HomeRuns: 2, RBI: 3, HitByPitch: 1, Walks: 1, Strikeouts: 7, GroundedIntoDoublePlays: 2, LeftOnBase: 3, PitchersUsed: 1, Putouts: 27, Assists: 12},
+							OpponentStats:           TeamStats{AtBats: 30, Hits: 3, Strikeouts: 9, LeftOnBase: 3, PitchersUsed: 5, IndividualEarnedRuns: 3, TeamEarnedRuns: 3, Putouts: 24, Assists: 11, Errors: 1, DoublePlays: 2},
+							IsHome:                  true,
+							DayNight:                Night,
+							LengthInOuts:            51,
+							TeamScore:               3,
+							OpponentScore:           1,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "001000000",
+							TeamLineScore:           "00200001x",
+							Result:                  Win,
+							ResultDate:              time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
 						},
 						{
-							Date:               time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
-							Team:               "CHN",
-							Franchise:          "CHN",
-							OpponentTeam:       "MIL",
-							OpponentFranchise:  "MIL",
-							OpponentGameNumber: 2,
-							TeamGameNumber:     2,
-							OpponentScore:      15,
-							TeamScore:          7,
-							ForfeitInfo:        "",
-							OpponentLineScore:  "000(10)02030",
-							TeamLineScore:      "20101120x",
-							Result:             Loss,
+							GameID:                  "CHN200107242",
+							Date:                    time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
+							GameNumber:              2,
+							Team:                    "CHN",
+							Franchise:               "CHN",
+							League:                  "NL",
+							OpponentTeam:            "MIL",
+							OpponentFranchise:       "MIL",
+							OpponentLeague:          "AL",
+							OpponentGameNumber:      2,
+							TeamGameNumber:          2,
+							Manager:                 Person{ID: "belld002", Name: "David Bell"},
+							OpponentManager:         Person{ID: "gardr001", Name: "Ron Gardenhire"},
+							StartingPitcher:         Person{ID: "grays001", Name: "Sonny Gray"},
+							OpponentStartingPitcher: Person{ID: "boydm001", Name: "Matt Boyd"},
+							Lineup:                  grays001Lineup,
+							OpponentLineup:          boydm001Lineup,
+							Stats:                   TeamStats{AtBats: 31, Hits: 9, Doubles: 1, HomeRuns: 2, RBI: 7, HitByPitch: 2, Walks: 4, Strikeouts: 4, StolenBases: 1, GroundedIntoDoublePlays: 1, LeftOnBase: 6, PitchersUsed: 4, IndividualEarnedRuns: 1, TeamEarnedRuns: 1, WildPitches: 1, Putouts: 27, Assists: 8, DoublePlays: 2},
+							OpponentStats:           TeamStats{AtBats: 28, Hits: 3, HomeRuns: 1, RBI: 1, Walks: 3, Strikeouts: 13, GroundedIntoDoublePlays: 2, LeftOnBase: 3, PitchersUsed: 5, IndividualEarnedRuns: 7, TeamEarnedRuns: 7, Putouts: 24, Assists: 9, DoublePlays: 2},
+							IsHome:                  true,
+							DayNight:                Night,
+							LengthInOuts:            51,
+							OpponentScore:           15,
+							TeamScore:               7,
+							Forfeit:                 NoForfeit,
+							OpponentLineScore:       "000(10)02030",
+							TeamLineScore:           "20101120x",
+							Result:                  Loss,
+							ResultDate:              time.Date(2001, time.July, 24, 0, 0, 0, 0, time.UTC),
 						},
 					},
 				},
@@ -320,3 +669,147 @@ func TestGetTeamsBySeason(t *testing.T) {
 	}
 	assert.Equal(t, expectedTeamsBySeason.m, teamsBySeason.m)
 }
+
+func TestParseLineScore(t *testing.T) {
+	tests := []struct {
+		name          string
+		lineScore     string
+		expected      LineScore
+		expectedRuns  []int
+		expectedError bool
+	}{
+		{
+			name:         "regulation",
+			lineScore:    "010000200",
+			expected:     LineScore{Innings: []LineScoreInning{{}, {Runs: 1}, {}, {}, {}, {}, {Runs: 2}, {}, {}}},
+			expectedRuns: []int{0, 1, 0, 0, 0, 0, 2, 0, 0},
+		},
+		{
+			name:         "multi-digit inning and did not bat",
+			lineScore:    "(12)1x",
+			expected:     LineScore{Innings: []LineScoreInning{{Runs: 12}, {Runs: 1}, {Status: DidNotBat}}},
+			expectedRuns: []int{12, 1},
+		},
+		{
+			name:         "blank",
+			lineScore:    "",
+			expected:     LineScore{Unknown: true},
+			expectedRuns: []int{},
+		},
+		{
+			name:         "partly blank",
+			lineScore:    "0 2 1",
+			expected:     LineScore{Innings: []LineScoreInning{{}, {Status: RunsUnknown}, {Runs: 2}, {Status: RunsUnknown}, {Runs: 1}}},
+			expectedRuns: []int{0, 2, 1},
+		},
+		{name: "unknown character", lineScore: "01?", expectedError: true},
+		{name: "unclosed parenthesis", lineScore: "0(10", expectedError: true},
+		{name: "inning after x", lineScore: "0x0", expectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ls, err := ParseLineScore(test.lineScore)
+			if test.expectedError {
+				var syntax *LineScoreSyntaxError
+				assert.ErrorAs(t, err, &syntax)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ls)
+			assert.Equal(t, test.expectedRuns, ls.Runs())
+		})
+	}
+}
+
+func TestLineScoresMismatch(t *testing.T) {
+	game := RetrosheetGame{
+		Date:              time.Date(1921, time.September, 4, 0, 0, 0, 0, time.UTC),
+		VisitingTeam:      "SLA",
+		HomeTeam:          "CHA",
+		VisitingScore:     11,
+		HomeScore:         3,
+		VisitingLineScore: "030011320",
+		HomeLineScore:     "000000300",
+	}
+	_, _, err := game.LineScores()
+	var mismatch *LineScoreMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, "SLA", mismatch.Team)
+	assert.Equal(t, 10, mismatch.LineScoreRuns)
+	assert.Equal(t, `1921-09-04 SLA @ CHA: SLA line score "030011320" adds up to 10 but the score is 11`, err.Error())
+
+	game.VisitingScore = 10
+	_, _, err = game.LineScores()
+	assert.NoError(t, err)
+}
+
+func TestLineScoresPartlyBlank(t *testing.T) {
+	game := RetrosheetGame{
+		Date:              time.Date(1884, time.June, 3, 0, 0, 0, 0, time.UTC),
+		VisitingTeam:      "BSN",
+		HomeTeam:          "PRO",
+		VisitingScore:     5,
+		HomeScore:         2,
+		VisitingLineScore: "10 00 001",
+		HomeLineScore:     "000200000",
+	}
+	visiting, home, err := game.LineScores()
+	require.NoError(t, err)
+	assert.False(t, visiting.Complete())
+	assert.True(t, home.Complete())
+	assert.Equal(t, 2, visiting.Total())
+
+	_, err = game.Progression()
+	assert.Error(t, err, "a partly blank line score can't be replayed")
+
+	// The known innings can't add up to more than the score.
+	game.VisitingLineScore = "10 00 006"
+	_, _, err = game.LineScores()
+	var mismatch *LineScoreMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, 7, mismatch.LineScoreRuns)
+}
+
+func TestParseCompletion(t *testing.T) {
+	completion, err := parseCompletion("20210924,SAN02,5,4,27")
+	require.NoError(t, err)
+	assert.Equal(t, &Completion{
+		Date:          time.Date(2021, time.September, 24, 0, 0, 0, 0, time.UTC),
+		Park:          "SAN02",
+		VisitingScore: 5,
+		HomeScore:     4,
+		Outs:          27,
+	}, completion)
+
+	completion, err = parseCompletion("19190727,,0,2,36")
+	require.NoError(t, err)
+	assert.Equal(t, "", completion.Park)
+	assert.Equal(t, 36, completion.Outs)
+
+	completion, err = parseCompletion("")
+	require.NoError(t, err)
+	assert.Nil(t, completion)
+
+	_, err = parseCompletion("20210924,SAN02")
+	assert.Error(t, err)
+}
+
+func TestResultModes(t *testing.T) {
+	game := RetrosheetGame{
+		Date:          time.Date(1977, time.June, 4, 0, 0, 0, 0, time.UTC),
+		VisitingScore: 5,
+		HomeScore:     5,
+		Forfeit:       ForfeitToVisitor,
+		Completion:    &Completion{Date: time.Date(1977, time.June, 5, 0, 0, 0, 0, time.UTC)},
+	}
+	assert.Equal(t, Loss, game.HomeResult(OfficialResult))
+	assert.Equal(t, Win, game.VisitorResult(OfficialResult))
+	assert.Equal(t, Tie, game.HomeResult(OnFieldResult))
+	assert.Equal(t, Tie, game.VisitorResult(OnFieldResult))
+	assert.Equal(t, game.Completion.Date, game.ResultDate())
+
+	_, err := parseResultMode("replay")
+	assert.Error(t, err)
+	assert.True(t, UpheldProtestByHome.Upheld())
+	assert.False(t, ProtestByHome.Upheld())
+}
@@ -19,6 +19,14 @@ examples and usage of using your application. For example:
 Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		mode, err := cmd.Flags().GetString("result")
+		if err != nil {
+			return err
+		}
+		resultMode, err = parseResultMode(mode)
+		return err
+	},
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
@@ -39,6 +47,8 @@ func init() {
 	// will be global for your application.
 
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.mlb-season-comparer.yaml)")
+	rootCmd.PersistentFlags().StringVar(&rsDataDir, "data-dir", rsDataDir, "Retrosheet data directory, with game logs under games/ and misc/CurrentNames.csv")
+	rootCmd.PersistentFlags().String("result", "official", "count forfeited games by their official result or the on-field score: official or on-field")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -0,0 +1,714 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+	// maxMatchWindows caps how many window lengths one match search may
+	// cover, since each adds a pass over every season.
+	maxMatchWindows = 20
+	// maxMatchSearch caps how many windows, over every season and length,
+	// one match search may compare. Each is held in memory until the search
+	// ends, so wider searches must narrow the seasons first.
+	maxMatchSearch = 500000
+)
+
+// Page is one page of a list endpoint's results. Total counts every result
+// matching the filters, not just those on the page.
+type Page struct {
+	Total  int
+	Offset int
+	Limit  int
+	Items  interface{}
+}
+
+// SeasonSummary is a season as the API lists it.
+type SeasonSummary struct {
+	Franchise string
+	Team      string
+	Year      int
+	League    string
+	Games     int
+	Record    SeasonRecord
+	Pct       float64
+}
+
+func summarizeSeason(s *Season) SeasonSummary {
+	summary := SeasonSummary{Franchise: s.Franchise, Team: s.Team, Year: s.Year, Games: len(s.Games), Record: s.GetSeasonRecord()}
+	summary.Pct = summary.Record.Pct()
+	if len(s.Games) > 0 {
+		summary.League = s.Games[0].League
+	}
+	return summary
+}
+
+// APIGame is one team's view of a game as the API lists it.
+type APIGame struct {
+	Date              string
+	GameNumber        int
+	TeamGameNumber    int
+	Franchise         string
+	Team              string
+	OpponentFranchise string
+	Opponent          string
+	IsHome            bool
+	TeamScore         int
+	OpponentScore     int
+	Result            string
+}
+
+func newAPIGame(game TeamGame) APIGame {
+	return APIGame{
+		Date:              game.Date.Format("2006-01-02"),
+		GameNumber:        game.GameNumber,
+		TeamGameNumber:    game.TeamGameNumber,
+		Franchise:         game.Franchise,
+		Team:              game.Team,
+		OpponentFranchise: game.OpponentFranchise,
+		Opponent:          game.OpponentTeam,
+		IsHome:            game.IsHome,
+		TeamScore:         game.TeamScore,
+		OpponentScore:     game.OpponentScore,
+		Result:            resultCode(game.Result),
+	}
+}
+
+// resultCode is the letter the transform command writes for a result.
+func resultCode(result Result) string {
+	switch result {
+	case Win:
+		return "W"
+	case Loss:
+		return "L"
+	default:
+		return "T"
+	}
+}
+
+// APIStreak is a run of consecutive wins or losses within a season.
+type APIStreak struct {
+	Franchise string
+	Team      string
+	Year      int
+	Result    string
+	Length    int
+	StartGame int
+	EndGame   int
+	StartDate string
+	EndDate   string
+}
+
+// seasonStreaks returns every streak of result within the season at least
+// minLength games long. Ties end a streak.
+func seasonStreaks(s *Season, result Result, minLength int) []APIStreak {
+	var streaks []APIStreak
+	start := -1
+	flush := func(end int) {
+		if start >= 0 && end-start >= minLength {
+			streaks = append(streaks, APIStreak{
+				Franchise: s.Franchise,
+				Team:      s.Team,
+				Year:      s.Year,
+				Result:    resultCode(result),
+				Length:    end - start,
+				StartGame: start + 1,
+				EndGame:   end,
+				StartDate: s.Games[start].Date.Format("2006-01-02"),
+				EndDate:   s.Games[end-1].Date.Format("2006-01-02"),
+			})
+		}
+		start = -1
+	}
+	for i, game := range s.Games {
+		if game.Result != result {
+			flush(i)
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	flush(len(s.Games))
+	return streaks
+}
+
+// longestStreaks returns the streaks of result in every season, longest
+// first.
+func longestStreaks(seasons []*Season, result Result, minLength int) []APIStreak {
+	streaks := []APIStreak{}
+	for _, season := range seasons {
+		streaks = append(streaks, seasonStreaks(season, result, minLength)...)
+	}
+	sort.SliceStable(streaks, func(i, j int) bool { return streaks[i].Length > streaks[j].Length })
+	return streaks
+}
+
+// APIRecordHit is the first stretch of a season with the requested record.
+type APIRecordHit struct {
+	Franchise    string
+	Team         string
+	Year         int
+	StartGame    int
+	EndGame      int
+	SeasonRecord SeasonRecord
+}
+
+// recordHits finds the seasons with a stretch of exactly wins and losses.
+func recordHits(seasons []*Season, wins, losses int) []APIRecordHit {
+	hits := []APIRecordHit{}
+	for _, season := range seasons {
+		if subset := seasonMatchesRecord(season, wins, losses, 0); subset != nil {
+			hits = append(hits, APIRecordHit{
+				Franchise:    season.Franchise,
+				Team:         season.Team,
+				Year:         season.Year,
+				StartGame:    subset.Start,
+				EndGame:      subset.End,
+				SeasonRecord: season.GetSeasonRecord(),
+			})
+		}
+	}
+	return hits
+}
+
+// APIMatchWindow is one season's stretch in a sequence match.
+type APIMatchWindow struct {
+	Team      string
+	Year      int
+	StartGame int
+	EndGame   int
+}
+
+// APIMatch is a W/L sequence shared by two or more season stretches.
+type APIMatch struct {
+	Results string
+	Games   int
+	Wins    int
+	Losses  int
+	Windows []APIMatchWindow
+}
+
+// seasonIndex holds every season loaded once, for commands that answer many
+// queries. The seasons are only read after loading, so queries need no
+// locking.
+type seasonIndex struct {
+	seasons []*Season // by year, then franchise
+}
+
+func newSeasonIndex(btbs *ByTeamsBySeason) *seasonIndex {
+	var seasons []*Season
+	for _, franchiseSeasons := range btbs.BySortedSeason() {
+		seasons = append(seasons, franchiseSeasons...)
+	}
+	sort.Slice(seasons, func(i, j int) bool {
+		if seasons[i].Year != seasons[j].Year {
+			return seasons[i].Year < seasons[j].Year
+		}
+		return seasons[i].Franchise < seasons[j].Franchise
+	})
+	return &seasonIndex{seasons: seasons}
+}
+
+// seasonMatches runs the compare command's search over the seasons, returning
+// the W/L sequences of minWindow through maxWindow games that two or more
+// share, longest first.
+func seasonMatches(seasons []*Season, minWindow, maxWindow, winningConstraint int) ([]APIMatch, error) {
+	records := [][]string{{"Year", "Team"}}
+	for _, season := range seasons {
+		record := []string{strconv.Itoa(season.Year), season.Team}
+		for _, game := range season.Games {
+			record = append(record, resultCode(game.Result))
+		}
+		records = append(records, record)
+	}
+	combos := newGameCombos()
+	if err := findMatches(records, combos, minWindow, maxWindow, winningConstraint); err != nil {
+		return nil, err
+	}
+	matches := []APIMatch{}
+	for _, results := range sortedMatches(combos) {
+		match := APIMatch{
+			Results: results,
+			Games:   len(results),
+			Wins:    strings.Count(results, "W"),
+			Losses:  strings.Count(results, "L"),
+		}
+		for _, detail := range combos.combos[results] {
+			year, _ := strconv.Atoi(detail.season)
+			match.Windows = append(match.Windows, APIMatchWindow{Team: detail.team, Year: year, StartGame: detail.gameStart, EndGame: detail.gameEnd})
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// matchSearchSize counts the windows of minWindow to maxWindow games that a
+// match search over the seasons compares.
+func matchSearchSize(seasons []*Season, minWindow, maxWindow int) int {
+	size := 0
+	for _, season := range seasons {
+		for window := minWindow; window <= maxWindow && window <= len(season.Games); window++ {
+			size += len(season.Games) - window + 1
+		}
+	}
+	return size
+}
+
+// checkMatchSearch refuses match searches of too many window lengths, or
+// over too many windows in all, to hold in memory.
+func checkMatchSearch(seasons []*Season, minWindow, maxWindow int) error {
+	if minWindow < 1 || maxWindow < minWindow || maxWindow-minWindow >= maxMatchWindows {
+		return fmt.Errorf("min must be at least 1 and max from min to min+%d", maxMatchWindows-1)
+	}
+	if size := matchSearchSize(seasons, minWindow, maxWindow); size > maxMatchSearch {
+		return fmt.Errorf("search covers %d windows, more than %d; narrow it with franchise, year, since, until or league, or fewer lengths", size, maxMatchSearch)
+	}
+	return nil
+}
+
+// apiServer answers HTTP queries over a season index.
+type apiServer struct {
+	*seasonIndex
+}
+
+func newAPIServer(btbs *ByTeamsBySeason) *apiServer {
+	return &apiServer{newSeasonIndex(btbs)}
+}
+
+func (s *apiServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/seasons", s.handleSeasons)
+	mux.HandleFunc("/seasons/", s.handleSeason)
+	mux.HandleFunc("/games", s.handleGames)
+	mux.HandleFunc("/streaks", s.handleStreaks)
+	mux.HandleFunc("/records", s.handleRecords)
+	mux.HandleFunc("/matches", s.handleMatches)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("no such endpoint %s", r.URL.Path))
+	})
+	return getOnly(mux)
+}
+
+// getOnly rejects every method but GET, since the API is read only.
+func getOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeAPIError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	writeAPIJSON(w, status, struct{ Error string }{err.Error()})
+}
+
+// queryInt reads an integer query parameter, returning def when it is
+// absent.
+func queryInt(q url.Values, name string, def int) (int, error) {
+	value := q.Get(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %q is not a number", name, value)
+	}
+	return n, nil
+}
+
+// seasonFilter is the franchise, year, since, until and league filters
+// shared by every endpoint that searches seasons.
+type seasonFilter struct {
+	franchise    string
+	league       string
+	since, until int
+}
+
+func parseSeasonFilter(q url.Values) (seasonFilter, error) {
+	f := seasonFilter{franchise: q.Get("franchise"), league: q.Get("league")}
+	year, err := queryInt(q, "year", 0)
+	if err != nil {
+		return f, err
+	}
+	if f.since, err = queryInt(q, "since", year); err != nil {
+		return f, err
+	}
+	if f.until, err = queryInt(q, "until", year); err != nil {
+		return f, err
+	}
+	return f, nil
+}
+
+func (f seasonFilter) keep(s *Season) bool {
+	if f.franchise != "" && s.Franchise != f.franchise {
+		return false
+	}
+	if f.since != 0 && s.Year < f.since {
+		return false
+	}
+	if f.until != 0 && s.Year > f.until {
+		return false
+	}
+	if f.league != "" && (len(s.Games) == 0 || s.Games[0].League != f.league) {
+		return false
+	}
+	return true
+}
+
+func (idx *seasonIndex) filterSeasons(f seasonFilter) []*Season {
+	var seasons []*Season
+	for _, season := range idx.seasons {
+		if f.keep(season) {
+			seasons = append(seasons, season)
+		}
+	}
+	return seasons
+}
+
+// pageRequest is the page a list request asks for with its offset and
+// limit query parameters.
+type pageRequest struct {
+	offset, limit int
+}
+
+func parsePageRequest(q url.Values) (pageRequest, error) {
+	var p pageRequest
+	var err error
+	if p.offset, err = queryInt(q, "offset", 0); err != nil {
+		return p, err
+	}
+	if p.limit, err = queryInt(q, "limit", defaultPageLimit); err != nil {
+		return p, err
+	}
+	if p.offset < 0 || p.limit < 1 || p.limit > maxPageLimit {
+		return p, fmt.Errorf("offset must be at least 0 and limit between 1 and %d", maxPageLimit)
+	}
+	return p, nil
+}
+
+// bounds returns the slice bounds of the page within total results.
+func (p pageRequest) bounds(total int) (int, int) {
+	lo, hi := p.offset, p.offset+p.limit
+	if lo > total {
+		lo = total
+	}
+	if hi > total {
+		hi = total
+	}
+	return lo, hi
+}
+
+func (p pageRequest) of(total int, items interface{}) Page {
+	return Page{Total: total, Offset: p.offset, Limit: p.limit, Items: items}
+}
+
+// handleSeasons lists seasons.
+func (s *apiServer) handleSeasons(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, err := parsePageRequest(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	f, err := parseSeasonFilter(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	summaries := []SeasonSummary{}
+	for _, season := range s.filterSeasons(f) {
+		summaries = append(summaries, summarizeSeason(season))
+	}
+	lo, hi := page.bounds(len(summaries))
+	writeAPIJSON(w, http.StatusOK, page.of(len(summaries), summaries[lo:hi]))
+}
+
+// handleSeason serves /seasons/FRANCHISE/YEAR and, with the same filters as
+// /games, /seasons/FRANCHISE/YEAR/games.
+func (s *apiServer) handleSeason(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/seasons/"), "/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != "games") {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("no such endpoint %s", r.URL.Path))
+		return
+	}
+	year, err := strconv.Atoi(parts[1])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("year: %q is not a number", parts[1]))
+		return
+	}
+	season := s.findSeason(parts[0], year)
+	if season == nil {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("no games found for %s in %d", parts[0], year))
+		return
+	}
+	if len(parts) == 2 {
+		writeAPIJSON(w, http.StatusOK, summarizeSeason(season))
+		return
+	}
+	q := r.URL.Query()
+	page, err := parsePageRequest(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	keep, err := parseGameFilter(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	games := filterGames([]*Season{season}, keep)
+	lo, hi := page.bounds(len(games))
+	writeAPIJSON(w, http.StatusOK, page.of(len(games), games[lo:hi]))
+}
+
+func (idx *seasonIndex) findSeason(franchise string, year int) *Season {
+	i := sort.Search(len(idx.seasons), func(i int) bool {
+		if idx.seasons[i].Year != year {
+			return idx.seasons[i].Year > year
+		}
+		return idx.seasons[i].Franchise >= franchise
+	})
+	if i < len(idx.seasons) && idx.seasons[i].Year == year && idx.seasons[i].Franchise == franchise {
+		return idx.seasons[i]
+	}
+	return nil
+}
+
+// parseGameFilter reads the opponent, result, home and date filters of a
+// game listing.
+func parseGameFilter(q url.Values) (func(TeamGame) bool, error) {
+	opponent, result, date := q.Get("opponent"), q.Get("result"), q.Get("date")
+	if result != "" && result != "W" && result != "L" && result != "T" {
+		return nil, fmt.Errorf("result: %q is not W, L or T", result)
+	}
+	var home *bool
+	if value := q.Get("home"); value != "" {
+		isHome, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("home: %q is not true or false", value)
+		}
+		home = &isHome
+	}
+	return func(game TeamGame) bool {
+		return (opponent == "" || game.OpponentFranchise == opponent) &&
+			(result == "" || resultCode(game.Result) == result) &&
+			(home == nil || game.IsHome == *home) &&
+			(date == "" || game.Date.Format("2006-01-02") == date)
+	}, nil
+}
+
+func filterGames(seasons []*Season, keep func(TeamGame) bool) []APIGame {
+	games := []APIGame{}
+	for _, season := range seasons {
+		for _, game := range season.Games {
+			if keep(game) {
+				games = append(games, newAPIGame(game))
+			}
+		}
+	}
+	return games
+}
+
+// handleGames lists games of the filtered seasons in season then game order.
+func (s *apiServer) handleGames(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, err := parsePageRequest(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	f, err := parseSeasonFilter(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	keep, err := parseGameFilter(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	games := filterGames(s.filterSeasons(f), keep)
+	lo, hi := page.bounds(len(games))
+	writeAPIJSON(w, http.StatusOK, page.of(len(games), games[lo:hi]))
+}
+
+// handleStreaks lists winning streaks, or losing streaks with type=loss,
+// longest first.
+func (s *apiServer) handleStreaks(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, err := parsePageRequest(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	f, err := parseSeasonFilter(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	result := Win
+	switch q.Get("type") {
+	case "", "win":
+	case "loss":
+		result = Loss
+	default:
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("type: %q is not win or loss", q.Get("type")))
+		return
+	}
+	minLength, err := queryInt(q, "min-length", 1)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	streaks := longestStreaks(s.filterSeasons(f), result, minLength)
+	lo, hi := page.bounds(len(streaks))
+	writeAPIJSON(w, http.StatusOK, page.of(len(streaks), streaks[lo:hi]))
+}
+
+// handleRecords finds the seasons with a stretch of exactly the given wins
+// and losses, as the recordInSeason command does.
+func (s *apiServer) handleRecords(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, err := parsePageRequest(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	f, err := parseSeasonFilter(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	wins, err := queryInt(q, "wins", 0)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	losses, err := queryInt(q, "losses", 0)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	if wins < 0 || losses < 0 || wins+losses == 0 {
+		writeAPIError(w, http.StatusBadRequest, errors.New("wins and losses must not be negative, and at least one must be set"))
+		return
+	}
+	hits := recordHits(s.filterSeasons(f), wins, losses)
+	lo, hi := page.bounds(len(hits))
+	writeAPIJSON(w, http.StatusOK, page.of(len(hits), hits[lo:hi]))
+}
+
+// handleMatches runs the compare command's search over the filtered seasons,
+// listing the W/L sequences of min through max games that two or more
+// seasons share, longest first.
+func (s *apiServer) handleMatches(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	page, err := parsePageRequest(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	f, err := parseSeasonFilter(q)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	minWindow, err := queryInt(q, "min", 0)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	maxWindow, err := queryInt(q, "max", minWindow)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	winningConstraint, err := queryInt(q, "winning-constraint", 0)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	seasons := s.filterSeasons(f)
+	if err := checkMatchSearch(seasons, minWindow, maxWindow); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err)
+		return
+	}
+	matches, err := seasonMatches(seasons, minWindow, maxWindow, winningConstraint)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+	lo, hi := page.bounds(len(matches))
+	writeAPIJSON(w, http.StatusOK, page.of(len(matches), matches[lo:hi]))
+}
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve seasons, games, streaks and searches over HTTP as JSON",
+	Long: `Loads the Retrosheet game logs once and answers GET requests with JSON until stopped.
+
+Endpoints:
+
+/seasons                      Seasons with their records.
+/seasons/FRANCHISE/YEAR       One season.
+/seasons/FRANCHISE/YEAR/games The season's games.
+/games                        Games, in season and game order.
+/streaks                      Winning streaks longest first; type=loss for losing streaks,
+                              min-length to drop short ones.
+/records                      The first stretch of each season with exactly wins and losses.
+/matches                      W/L sequences of min to max games shared by two or more seasons,
+                              longest first, as the compare command finds; winning-constraint
+                              as in compare. Searches of more than 500000 windows, summed over
+                              every season and length, are refused: filter the seasons first.
+
+Every endpoint but a single season takes the filters franchise, year, since, until and league.
+The game listings also take opponent (a franchise), result (W, L or T), home (true or false) and
+date (YYYY-MM-DD). Lists come a page at a time: limit (default 100, at most 1000) and offset pick
+the page, and Total counts every result. Errors come back as {"Error": "..."}.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, err := cmd.Flags().GetString("addr")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Listening on %s\n", addr)
+		return http.ListenAndServe(addr, newAPIServer(teamsBySeason).Handler())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().String("addr", "localhost:8080", "address to listen on")
+}
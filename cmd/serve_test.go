@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAPIServer(t *testing.T) *httptest.Server {
+	teamsBySeason, err := GetTeamsBySeason("./test_data")
+	require.NoError(t, err)
+	server := httptest.NewServer(newAPIServer(teamsBySeason).Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// getJSON requests path and decodes the response into v, returning the
+// status code.
+func getJSON(t *testing.T, server *httptest.Server, path string, v interface{}) int {
+	resp, err := http.Get(server.URL + path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	return resp.StatusCode
+}
+
+func TestServeSeasons(t *testing.T) {
+	server := newTestAPIServer(t)
+
+	var page struct {
+		Page
+		Items []SeasonSummary
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, server, "/seasons", &page))
+	assert.Equal(t, 8, page.Total)
+	require.Len(t, page.Items, 8)
+	assert.Equal(t, 2000, page.Items[0].Year)
+	assert.Equal(t, 2001, page.Items[7].Year)
+
+	require.Equal(t, http.StatusOK, getJSON(t, server, "/seasons?franchise=SFN&limit=1&offset=1", &page))
+	assert.Equal(t, 2, page.Total)
+	assert.Equal(t, 1, page.Limit)
+	assert.Equal(t, 1, page.Offset)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, 2001, page.Items[0].Year)
+	assert.Equal(t, SeasonRecord{Wins: 1, Losses: 1}, page.Items[0].Record)
+
+	require.Equal(t, http.StatusOK, getJSON(t, server, "/seasons?year=2001&offset=50", &page))
+	assert.Equal(t, 4, page.Total)
+	assert.Empty(t, page.Items)
+
+	var season SeasonSummary
+	require.Equal(t, http.StatusOK, getJSON(t, server, "/seasons/SFN/2000", &season))
+	assert.Equal(t, SeasonSummary{Franchise: "SFN", Team: "SFN", Year: 2000, League: "NL", Games: 2, Record: SeasonRecord{Wins: 1, Losses: 1}, Pct: 0.5}, season)
+}
+
+func TestServeGames(t *testing.T) {
+	server := newTestAPIServer(t)
+
+	var page struct {
+		Page
+		Items []APIGame
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, server, "/seasons/SFN/2000/games", &page))
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, APIGame{
+		Date:              "2000-07-23",
+		GameNumber:        1,
+		TeamGameNumber:    1,
+		Franchise:         "SFN",
+		Team:              "SFN",
+		OpponentFranchise: "LAN",
+		Opponent:          "LAN",
+		TeamScore:         1,
+		OpponentScore:     8,
+		Result:            "L",
+	}, page.Items[0])
+
+	require.Equal(t, http.StatusOK, getJSON(t, server, "/games?franchise=SFN&result=W", &page))
+	assert.Equal(t, 2, page.Total)
+	for _, game := range page.Items {
+		assert.Equal(t, "W", game.Result)
+	}
+
+	require.Equal(t, http.StatusOK, getJSON(t, server, "/games?home=true&date=2000-07-24", &page))
+	require.Len(t, page.Items, 2)
+	for _, game := range page.Items {
+		assert.Equal(t, "CHN", game.Franchise)
+	}
+}
+
+func TestServeStreaksRecordsAndMatches(t *testing.T) {
+	server := newTestAPIServer(t)
+
+	var streaks struct {
+		Page
+		Items []APIStreak
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, server, "/streaks?min-length=2", &streaks))
+	require.NotEmpty(t, streaks.Items)
+	for i, streak := range streaks.Items {
+		assert.Equal(t, "W", streak.Result)
+		assert.GreaterOrEqual(t, streak.Length, 2)
+		assert.Equal(t, streak.Length, streak.EndGame-streak.StartGame+1)
+		if i > 0 {
+			assert.LessOrEqual(t, streak.Length, streaks.Items[i-1].Length)
+		}
+	}
+
+	var records struct {
+		Page
+		Items []APIRecordHit
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, server, "/records?wins=1&losses=1&franchise=SFN", &records))
+	assert.Equal(t, []APIRecordHit{
+		{Franchise: "SFN", Team: "SFN", Year: 2000, StartGame: 1, EndGame: 2, SeasonRecord: SeasonRecord{Wins: 1, Losses: 1}},
+		{Franchise: "SFN", Team: "SFN", Year: 2001, StartGame: 1, EndGame: 2, SeasonRecord: SeasonRecord{Wins: 1, Losses: 1}},
+	}, records.Items)
+
+	var matches struct {
+		Page
+		Items []APIMatch
+	}
+	require.Equal(t, http.StatusOK, getJSON(t, server, "/matches?min=2&franchise=SFN", &matches))
+	assert.Equal(t, []APIMatch{{
+		Results: "LW",
+		Games:   2,
+		Wins:    1,
+		Losses:  1,
+		Windows: []APIMatchWindow{
+			{Team: "SFN", Year: 2000, StartGame: 1, EndGame: 2},
+			{Team: "SFN", Year: 2001, StartGame: 1, EndGame: 2},
+		},
+	}}, matches.Items)
+}
+
+func TestMatchSearchSize(t *testing.T) {
+	seasons := []*Season{
+		testSeason("SFN", 2000, "NL", Win, Win, Loss, Win, Loss),
+		testSeason("NYA", 2000, "AL", Win, Loss),
+	}
+	assert.Equal(t, 4+1, matchSearchSize(seasons, 2, 2))
+	assert.Equal(t, (4+3+2+1)+1, matchSearchSize(seasons, 2, 10))
+	assert.Zero(t, matchSearchSize(seasons, 6, 8))
+
+}
+
+func TestServeErrors(t *testing.T) {
+	server := newTestAPIServer(t)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{path: "/teams", status: http.StatusNotFound},
+		{path: "/seasons/SFN/1900", status: http.StatusNotFound},
+		{path: "/seasons/SFN/2000/innings", status: http.StatusNotFound},
+		{path: "/seasons/SFN/two", status: http.StatusBadRequest},
+		{path: "/seasons?year=two", status: http.StatusBadRequest},
+		{path: "/games?limit=0", status: http.StatusBadRequest},
+		{path: "/games?limit=5000", status: http.StatusBadRequest},
+		{path: "/games?result=X", status: http.StatusBadRequest},
+		{path: "/streaks?type=tie", status: http.StatusBadRequest},
+		{path: "/records", status: http.StatusBadRequest},
+		{path: "/matches?min=2&max=40", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		var body struct{ Error string }
+		assert.Equal(t, tt.status, getJSON(t, server, tt.path, &body), tt.path)
+		assert.NotEmpty(t, body.Error, tt.path)
+	}
+
+	resp, err := http.Post(server.URL+"/seasons", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
@@ -0,0 +1,512 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// seasonFilterParams are the parameters every shell query takes to pick its
+// seasons, as the serve command's endpoints do.
+var seasonFilterParams = []string{"franchise", "year", "since", "until", "league"}
+
+// shellCommand is a query the shell runs. Its parameters are given as
+// name=value words and read like the serve command's query parameters.
+type shellCommand struct {
+	usage  string
+	help   string
+	params []string
+	run    func(sh *shellSession, w io.Writer, q url.Values) error
+}
+
+var shellCommands = map[string]shellCommand{
+	"seasons": {
+		usage:  "seasons [top=N]",
+		help:   "List seasons with their records.",
+		params: []string{"top"},
+		run:    (*shellSession).seasons,
+	},
+	"compare": {
+		usage: "compare min=N [max=N] [winning-constraint=PCT]",
+		help: "Find W/L sequences of min through max games shared by two or more seasons, longest first. " +
+			"As with the server's /matches, max may be at most min+19 and a search may cover at most 500000 windows.",
+		params: []string{"min", "max", "winning-constraint"},
+		run:    (*shellSession).compare,
+	},
+	"streak": {
+		usage:  "streak [type=win|loss] [min-length=N] [top=N]",
+		help:   "List the longest winning or losing streaks within a season. Ties end a streak.",
+		params: []string{"type", "min-length", "top"},
+		run:    (*shellSession).streak,
+	},
+	"record": {
+		usage:  "record wins=N losses=N",
+		help:   "Find the first stretch of each season with exactly the given wins and losses.",
+		params: []string{"wins", "losses"},
+		run:    (*shellSession).record,
+	},
+	"inning": {
+		usage: "inning",
+		help: "Give, by year, the percentage of games in which a team scored more in one inning than " +
+			"its opponent did all game. With a franchise, only its games count.",
+		run: (*shellSession).inning,
+	},
+}
+
+// shellBuiltins are the shell's own commands, which take no parameters.
+var shellBuiltins = []string{"help", "history", "exit", "quit"}
+
+// shellSession answers queries over seasons loaded once, keeping the
+// franchises and years for completion and the queries run so far.
+type shellSession struct {
+	index      *seasonIndex
+	franchises []string
+	years      []string
+	leagues    []string
+	history    []string
+}
+
+func newShellSession(index *seasonIndex) *shellSession {
+	sh := &shellSession{index: index}
+	franchises, years, leagues := map[string]bool{}, map[int]bool{}, map[string]bool{}
+	for _, season := range index.seasons {
+		franchises[season.Franchise] = true
+		years[season.Year] = true
+		if len(season.Games) > 0 && season.Games[0].League != "" {
+			leagues[season.Games[0].League] = true
+		}
+	}
+	for franchise := range franchises {
+		sh.franchises = append(sh.franchises, franchise)
+	}
+	sort.Strings(sh.franchises)
+	var sortedYears []int
+	for year := range years {
+		sortedYears = append(sortedYears, year)
+	}
+	sort.Ints(sortedYears)
+	for _, year := range sortedYears {
+		sh.years = append(sh.years, strconv.Itoa(year))
+	}
+	for league := range leagues {
+		sh.leagues = append(sh.leagues, league)
+	}
+	sort.Strings(sh.leagues)
+	return sh
+}
+
+// Execute runs one line of input, writing its output to w or, when the line
+// ends in "> FILE" or ">> FILE", to that file, replaced or appended to. "!N"
+// runs the Nth line of the history again.
+func (sh *shellSession) Execute(line string, w io.Writer) error {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "!") {
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 1 || n > len(sh.history) {
+			return fmt.Errorf("no history entry %q", line[1:])
+		}
+		line = sh.history[n-1]
+		fmt.Fprintln(w, line)
+	}
+	sh.history = append(sh.history, line)
+
+	line, path, appending := parseRedirect(line)
+	if path != "" {
+		flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+		if appending {
+			flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		}
+		f, err := os.OpenFile(path, flags, 0o644)
+		if err != nil {
+			return err
+		}
+		if err := sh.run(line, f); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+	return sh.run(line, w)
+}
+
+// parseRedirect splits a trailing "> FILE" or ">> FILE" off a line.
+func parseRedirect(line string) (string, string, bool) {
+	i := strings.LastIndex(line, ">")
+	if i < 0 {
+		return line, "", false
+	}
+	path := strings.TrimSpace(line[i+1:])
+	appending := i > 0 && line[i-1] == '>'
+	if appending {
+		i--
+	}
+	return strings.TrimSpace(line[:i]), path, appending
+}
+
+func (sh *shellSession) run(line string, w io.Writer) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return errors.New("no command given")
+	}
+	switch fields[0] {
+	case "help":
+		return sh.help(w, fields[1:])
+	case "history":
+		for i, entry := range sh.history {
+			fmt.Fprintf(w, "%4d  %s\n", i+1, entry)
+		}
+		return nil
+	}
+	command, ok := shellCommands[fields[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q; try help", fields[0])
+	}
+	q := url.Values{}
+	for _, field := range fields[1:] {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("%q: expected NAME=VALUE", field)
+		}
+		if !command.takes(name) {
+			return fmt.Errorf("%s takes no parameter %q", fields[0], name)
+		}
+		q.Set(name, value)
+	}
+	return command.run(sh, w, q)
+}
+
+func (c shellCommand) takes(param string) bool {
+	for _, p := range append(c.params, seasonFilterParams...) {
+		if p == param {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedShellCommands() []string {
+	var names []string
+	for name := range shellCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (sh *shellSession) help(w io.Writer, args []string) error {
+	if len(args) > 0 {
+		command, ok := shellCommands[args[0]]
+		if !ok {
+			return fmt.Errorf("unknown command %q", args[0])
+		}
+		fmt.Fprintf(w, "%s\n  %s\n", command.usage, command.help)
+		return nil
+	}
+	for _, name := range sortedShellCommands() {
+		fmt.Fprintf(w, "%s\n  %s\n", shellCommands[name].usage, shellCommands[name].help)
+	}
+	fmt.Fprintf(w, `
+Every query also takes %s=VALUE to pick its seasons.
+End a line with "> FILE" to write its output to a file, or ">> FILE" to append.
+history lists the queries run so far and !N runs the Nth again. exit or quit leaves.
+Tab completes commands, parameters, franchises and years.
+`, strings.Join(seasonFilterParams, "=, "))
+	return nil
+}
+
+// seasonsAndTop reads the season filters and the number of rows to list,
+// which is all of them when top is 0.
+func (sh *shellSession) seasonsAndTop(q url.Values, defaultTop int) ([]*Season, int, error) {
+	f, err := parseSeasonFilter(q)
+	if err != nil {
+		return nil, 0, err
+	}
+	top, err := queryInt(q, "top", defaultTop)
+	if err != nil {
+		return nil, 0, err
+	}
+	return sh.index.filterSeasons(f), top, nil
+}
+
+func (sh *shellSession) seasons(w io.Writer, q url.Values) error {
+	seasons, top, err := sh.seasonsAndTop(q, 0)
+	if err != nil {
+		return err
+	}
+	for i, season := range seasons {
+		if top > 0 && i == top {
+			break
+		}
+		summary := summarizeSeason(season)
+		fmt.Fprintf(w, "%d %s\t%s\t%s\t%s\n", summary.Year, summary.Franchise, summary.League, summary.Record.String(), formatPct(summary.Pct))
+	}
+	return nil
+}
+
+func (sh *shellSession) compare(w io.Writer, q url.Values) error {
+	seasons, _, err := sh.seasonsAndTop(q, 0)
+	if err != nil {
+		return err
+	}
+	minWindow, err := queryInt(q, "min", 0)
+	if err != nil {
+		return err
+	}
+	maxWindow, err := queryInt(q, "max", minWindow)
+	if err != nil {
+		return err
+	}
+	winningConstraint, err := queryInt(q, "winning-constraint", 0)
+	if err != nil {
+		return err
+	}
+	if err := checkMatchSearch(seasons, minWindow, maxWindow); err != nil {
+		return err
+	}
+	matches, err := seasonMatches(seasons, minWindow, maxWindow, winningConstraint)
+	if err != nil {
+		return err
+	}
+	for _, match := range matches {
+		fmt.Fprintln(w, "Match Found: ", match.Results)
+		for _, window := range match.Windows {
+			fmt.Fprintf(w, "  %d %s games %d-%d\n", window.Year, window.Team, window.StartGame, window.EndGame)
+		}
+	}
+	return nil
+}
+
+func (sh *shellSession) streak(w io.Writer, q url.Values) error {
+	seasons, top, err := sh.seasonsAndTop(q, 10)
+	if err != nil {
+		return err
+	}
+	result := Win
+	switch q.Get("type") {
+	case "", "win":
+	case "loss":
+		result = Loss
+	default:
+		return fmt.Errorf("type: %q is not win or loss", q.Get("type"))
+	}
+	minLength, err := queryInt(q, "min-length", 1)
+	if err != nil {
+		return err
+	}
+	for i, streak := range longestStreaks(seasons, result, minLength) {
+		if top > 0 && i == top {
+			break
+		}
+		fmt.Fprintf(w, "%d %s\t%s%d\tgames %d-%d\t%s to %s\n", streak.Year, streak.Franchise, streak.Result, streak.Length,
+			streak.StartGame, streak.EndGame, streak.StartDate, streak.EndDate)
+	}
+	return nil
+}
+
+func (sh *shellSession) record(w io.Writer, q url.Values) error {
+	seasons, _, err := sh.seasonsAndTop(q, 0)
+	if err != nil {
+		return err
+	}
+	wins, err := queryInt(q, "wins", 0)
+	if err != nil {
+		return err
+	}
+	losses, err := queryInt(q, "losses", 0)
+	if err != nil {
+		return err
+	}
+	if wins < 0 || losses < 0 || wins+losses == 0 {
+		return errors.New("wins and losses must not be negative, and at least one must be set")
+	}
+	for _, hit := range recordHits(seasons, wins, losses) {
+		fmt.Fprintln(w, hit.Franchise, hit.Year, "Start", hit.StartGame, "End", hit.EndGame, "Record", hit.SeasonRecord.String())
+	}
+	return nil
+}
+
+func (sh *shellSession) inning(w io.Writer, q url.Values) error {
+	seasons, _, err := sh.seasonsAndTop(q, 0)
+	if err != nil {
+		return err
+	}
+	// Without a franchise every game is in two seasons, so count it once,
+	// from the home team's side.
+	keep := func(game TeamGame) bool { return game.IsHome }
+	if q.Get("franchise") != "" {
+		keep = func(TeamGame) bool { return true }
+	}
+	outscores, err := seasonInningOutscores(seasons, keep)
+	if err != nil {
+		return err
+	}
+	for _, season := range outscores {
+		fmt.Fprintf(w, "%d\t%s\n", season.season, season.PctString())
+	}
+	return nil
+}
+
+// Complete completes the word before pos in line: a command, a parameter
+// name, or a franchise, year, league or streak type. It returns the new line
+// and position, and the candidates when there is more than one.
+func (sh *shellSession) Complete(line string, pos int) (string, int, []string) {
+	prefix := line[:pos]
+	start := strings.LastIndex(prefix, " ") + 1
+	word := prefix[start:]
+
+	var candidates []string
+	if start == 0 || strings.TrimSpace(prefix[:start]) == "" {
+		candidates = append(sortedShellCommands(), shellBuiltins...)
+	} else if name, _, ok := strings.Cut(word, "="); ok {
+		var values []string
+		switch name {
+		case "franchise":
+			values = sh.franchises
+		case "year", "since", "until":
+			values = sh.years
+		case "league":
+			values = sh.leagues
+		case "type":
+			values = []string{"win", "loss"}
+		}
+		for _, value := range values {
+			candidates = append(candidates, name+"="+value)
+		}
+	} else {
+		fields := strings.Fields(prefix)
+		if fields[0] == "help" {
+			candidates = sortedShellCommands()
+		} else if command, ok := shellCommands[fields[0]]; ok {
+			for _, param := range append(command.params, seasonFilterParams...) {
+				candidates = append(candidates, param+"=")
+			}
+		}
+	}
+
+	var matching []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) {
+			matching = append(matching, candidate)
+		}
+	}
+	if len(matching) == 0 {
+		return line, pos, nil
+	}
+	completion := matching[0]
+	for _, candidate := range matching[1:] {
+		for !strings.HasPrefix(candidate, completion) {
+			completion = completion[:len(completion)-1]
+		}
+	}
+	if len(matching) == 1 && !strings.HasSuffix(completion, "=") {
+		completion += " "
+	}
+	var listed []string
+	if len(matching) > 1 && completion == word {
+		listed = matching
+	}
+	return prefix[:start] + completion + line[pos:], start + len(completion), listed
+}
+
+// runInteractive reads queries from the terminal with line editing, history
+// on the arrow keys and tab completion.
+func (sh *shellSession) runInteractive() error {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, "mlb> ")
+	t.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+		newLine, newPos, listed := sh.Complete(line, pos)
+		if len(listed) > 0 {
+			fmt.Fprintln(t, strings.Join(listed, "  "))
+		}
+		return newLine, newPos, true
+	}
+	for {
+		line, err := t.ReadLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if done := sh.handleLine(line, t); done {
+			return nil
+		}
+	}
+}
+
+// handleLine runs a line of input, reporting whether it asked to leave.
+func (sh *shellSession) handleLine(line string, w io.Writer) bool {
+	switch strings.TrimSpace(line) {
+	case "":
+		return false
+	case "exit", "quit":
+		return true
+	}
+	if err := sh.Execute(line, w); err != nil {
+		fmt.Fprintln(w, "error:", err)
+	}
+	return false
+}
+
+// shellCmd represents the shell command
+var shellCmd = &cobra.Command{
+	Use:   "shell",
+	Short: "Run compare, streak, record and inning queries interactively",
+	Long: `Loads the Retrosheet game logs once, then answers queries typed one per line, so changing a
+parameter doesn't mean parsing every game log again. Queries take NAME=VALUE parameters, e.g.
+
+  streak type=loss since=1990 top=5
+  record wins=20 losses=0 franchise=SFN
+  compare min=30 max=32 since=1950 > matches.txt
+
+Type help for every query and its parameters. Tab completes commands, parameters, franchises and
+years, the arrow keys step through earlier queries, and a trailing "> FILE" or ">> FILE" writes
+a query's output to a file. When standard input isn't a terminal the queries are read from it
+without a prompt.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		sh := newShellSession(newSeasonIndex(teamsBySeason))
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			return sh.runInteractive()
+		}
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if done := sh.handleLine(scanner.Text(), os.Stdout); done {
+				return nil
+			}
+		}
+		return scanner.Err()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(shellCmd)
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestShellSession(t *testing.T) *shellSession {
+	teamsBySeason, err := GetTeamsBySeason("./test_data")
+	require.NoError(t, err)
+	return newShellSession(newSeasonIndex(teamsBySeason))
+}
+
+func TestShellQueries(t *testing.T) {
+	sh := newTestShellSession(t)
+
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{
+			line:     "seasons franchise=SFN",
+			expected: "2000 SFN\tNL\t1-1\t.500\n2001 SFN\tNL\t1-1\t.500\n",
+		},
+		{
+			line:     "record wins=1 losses=1 franchise=SFN since=2001",
+			expected: "SFN 2001 Start 1 End 2 Record 1-1\n",
+		},
+		{
+			line:     "compare min=2 franchise=SFN",
+			expected: "Match Found:  LW\n  2000 SFN games 1-2\n  2001 SFN games 1-2\n",
+		},
+		{
+			line:     "streak type=loss franchise=SFN year=2000",
+			expected: "2000 SFN\tL1\tgames 1-1\t2000-07-23 to 2000-07-23\n",
+		},
+		{
+			line:     "inning franchise=SFN",
+			expected: "2000\t100.0\n2001\t100.0\n",
+		},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		require.NoError(t, sh.Execute(tt.line, &b), tt.line)
+		assert.Equal(t, tt.expected, b.String(), tt.line)
+	}
+}
+
+func TestSeasonInningOutscores(t *testing.T) {
+	sh := newTestShellSession(t)
+	outscores, err := seasonInningOutscores(sh.index.seasons, func(game TeamGame) bool { return game.IsHome })
+	require.NoError(t, err)
+	assert.Equal(t, []inningOutscorePerSeason{
+		{season: 2000, weirdGames: 4, totalGames: 4},
+		{season: 2001, weirdGames: 4, totalGames: 4},
+	}, outscores)
+	assert.Equal(t, 100.0, outscores[0].Pct())
+	assert.InDelta(t, 33.3, inningOutscorePerSeason{weirdGames: 1, totalGames: 3}.Pct(), 0.05)
+	assert.Equal(t, "33.3", inningOutscorePerSeason{weirdGames: 1, totalGames: 3}.PctString())
+}
+
+func TestShellErrors(t *testing.T) {
+	sh := newTestShellSession(t)
+	for _, line := range []string{
+		"standings",
+		"streak top",
+		"streak wins=3",
+		"streak type=tie",
+		"record",
+		"compare min=0",
+		"compare min=1 max=162",
+		"seasons year=two",
+		"!99",
+	} {
+		assert.Error(t, sh.Execute(line, &bytes.Buffer{}), line)
+	}
+}
+
+func TestShellRedirectAndHistory(t *testing.T) {
+	sh := newTestShellSession(t)
+	path := filepath.Join(t.TempDir(), "out.txt")
+
+	var b bytes.Buffer
+	require.NoError(t, sh.Execute("seasons franchise=SFN year=2000 > "+path, &b))
+	require.NoError(t, sh.Execute("seasons franchise=SFN year=2001 >> "+path, &b))
+	assert.Empty(t, b.String())
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2000 SFN\tNL\t1-1\t.500\n2001 SFN\tNL\t1-1\t.500\n", string(contents))
+
+	require.NoError(t, sh.Execute("seasons franchise=SFN year=2000 > "+path, &b))
+	contents, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2000 SFN\tNL\t1-1\t.500\n", string(contents))
+
+	require.NoError(t, sh.Execute("!1", &b))
+	assert.Equal(t, "seasons franchise=SFN year=2000 > "+path+"\n", b.String())
+
+	b.Reset()
+	require.NoError(t, sh.Execute("history", &b))
+	assert.Contains(t, b.String(), "   4  seasons franchise=SFN year=2000 > "+path+"\n")
+	assert.Contains(t, b.String(), "   5  history\n")
+}
+
+func TestParseRedirect(t *testing.T) {
+	tests := []struct {
+		line      string
+		command   string
+		path      string
+		appending bool
+	}{
+		{line: "streak top=5", command: "streak top=5"},
+		{line: "streak top=5 > out.txt", command: "streak top=5", path: "out.txt"},
+		{line: "streak top=5>>out.txt", command: "streak top=5", path: "out.txt", appending: true},
+	}
+	for _, tt := range tests {
+		command, path, appending := parseRedirect(tt.line)
+		assert.Equal(t, tt.command, command, tt.line)
+		assert.Equal(t, tt.path, path, tt.line)
+		assert.Equal(t, tt.appending, appending, tt.line)
+	}
+}
+
+func TestShellComplete(t *testing.T) {
+	sh := newTestShellSession(t)
+
+	tests := []struct {
+		line     string
+		expected string
+		listed   []string
+	}{
+		{line: "str", expected: "streak "},
+		{line: "s", expected: "s", listed: []string{"seasons", "streak"}},
+		{line: "streak ty", expected: "streak type="},
+		{line: "streak type=l", expected: "streak type=loss "},
+		{line: "record franchise=S", expected: "record franchise=SFN "},
+		{line: "record franchise=", expected: "record franchise=", listed: []string{"franchise=CHN", "franchise=LAN", "franchise=MIL", "franchise=SFN"}},
+		{line: "record since=200", expected: "record since=200", listed: []string{"since=2000", "since=2001"}},
+		{line: "help rec", expected: "help record "},
+		{line: "record nothing", expected: "record nothing"},
+	}
+	for _, tt := range tests {
+		line, pos, listed := sh.Complete(tt.line, len(tt.line))
+		assert.Equal(t, tt.expected, line, tt.line)
+		assert.Equal(t, len(tt.expected), pos, tt.line)
+		assert.Equal(t, tt.listed, listed, tt.line)
+	}
+
+	line, pos, _ := sh.Complete("record franchise=S wins=1", len("record franchise=S"))
+	assert.Equal(t, "record franchise=SFN  wins=1", line)
+	assert.Equal(t, len("record franchise=SFN "), pos)
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"hash/maphash"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// seasonModel describes how a single CSV season is simulated: the chance of a
+// win and of a tie in each game it actually played.
+type seasonModel struct {
+	season   string
+	team     string
+	winProbs []float64
+	tieProbs []float64
+}
+
+type seasonKey struct {
+	year int
+	team string
+}
+
+// homeSchedule is, for each season and team, whether each game in order was
+// played at home.
+type homeSchedule map[seasonKey][]bool
+
+// getHomeSchedule reads the home/away sequence of every team's season from
+// the Retrosheet game logs, keyed by team code as the transform command
+// writes it.
+func getHomeSchedule(dir string) (homeSchedule, error) {
+	type scheduledGame struct {
+		gameNumber int
+		isHome     bool
+	}
+	var mu sync.Mutex
+	games := make(map[seasonKey][]scheduledGame)
+	err := ByRetrosheetGame(dir, func(game *RetrosheetGame) error {
+		year := game.Date.Year()
+		mu.Lock()
+		defer mu.Unlock()
+		homeKey := seasonKey{year: year, team: game.HomeTeam}
+		games[homeKey] = append(games[homeKey], scheduledGame{gameNumber: game.HomeGameNumber, isHome: true})
+		visitingKey := seasonKey{year: year, team: game.VisitingTeam}
+		games[visitingKey] = append(games[visitingKey], scheduledGame{gameNumber: game.VisitingGameNumber})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := make(homeSchedule)
+	for key, sg := range games {
+		sort.Slice(sg, func(i, j int) bool { return sg[i].gameNumber < sg[j].gameNumber })
+		homeGames := make([]bool, len(sg))
+		for i, g := range sg {
+			homeGames[i] = g.isHome
+		}
+		schedule[key] = homeGames
+	}
+	return schedule, nil
+}
+
+// seasonModelsFromRecords builds a model per CSV row from the season's own
+// win and tie rates. When schedule has the season's home/away sequence, home
+// and road games are simulated with separate rates.
+func seasonModelsFromRecords(records [][]string, schedule homeSchedule) []seasonModel {
+	var models []seasonModel
+	for i, record := range records {
+		if i == 0 {
+			// Header row, skip
+			continue
+		}
+		season := record[0]
+		team := record[1]
+		var results []string
+		for _, result := range record[2:] {
+			if result != "" {
+				results = append(results, result)
+			}
+		}
+		if len(results) == 0 {
+			continue
+		}
+
+		var homeGames []bool
+		year, err := strconv.Atoi(season)
+		if err == nil {
+			homeGames = schedule[seasonKey{year: year, team: team}]
+		}
+		if len(homeGames) != len(results) {
+			homeGames = nil
+		}
+
+		// Index 0 counts road games, 1 home games. Without a schedule every
+		// game is counted as a road game.
+		var games, wins, ties [2]float64
+		for j, result := range results {
+			side := 0
+			if homeGames != nil && homeGames[j] {
+				side = 1
+			}
+			games[side]++
+			if result == "W" {
+				wins[side]++
+			} else if result == "T" {
+				ties[side]++
+			}
+		}
+
+		model := seasonModel{season: season, team: team}
+		for j := range results {
+			side := 0
+			if homeGames != nil && homeGames[j] {
+				side = 1
+			}
+			model.winProbs = append(model.winProbs, wins[side]/games[side])
+			model.tieProbs = append(model.tieProbs, ties[side]/games[side])
+		}
+		models = append(models, model)
+	}
+	return models
+}
+
+// simulate draws a W/L/T sequence for every season.
+func simulate(models []seasonModel, r *rand.Rand) [][]byte {
+	seqs := make([][]byte, len(models))
+	for i, model := range models {
+		seq := make([]byte, len(model.winProbs))
+		for j := range seq {
+			x := r.Float64()
+			switch {
+			case x < model.winProbs[j]:
+				seq[j] = 'W'
+			case x < model.winProbs[j]+model.tieProbs[j]:
+				seq[j] = 'T'
+			default:
+				seq[j] = 'L'
+			}
+		}
+		seqs[i] = seq
+	}
+	return seqs
+}
+
+// hasMatch reports whether any two windows of gameWindow games, in the same
+// or different seasons, are identical. Windows are filtered by
+// winningConstraint the same way compare filters them.
+func hasMatch(seqs [][]byte, gameWindow, winningConstraint int, seed maphash.Seed) bool {
+	seen := make(map[uint64]struct{})
+	for _, seq := range seqs {
+		var winsInResults int
+		for i := 0; i+gameWindow <= len(seq); i++ {
+			if i == 0 {
+				for _, result := range seq[:gameWindow] {
+					if result == 'W' {
+						winsInResults++
+					}
+				}
+			} else {
+				if seq[i-1] == 'W' {
+					winsInResults--
+				}
+				if seq[i+gameWindow-1] == 'W' {
+					winsInResults++
+				}
+			}
+			if winningConstraint > 0 && winsInResults*100/gameWindow < winningConstraint {
+				continue
+			}
+			h := maphash.Bytes(seed, seq[i:i+gameWindow])
+			if _, ok := seen[h]; ok {
+				return true
+			}
+			seen[h] = struct{}{}
+		}
+	}
+	return false
+}
+
+// longestMatch returns the longest game window between minGameWindow and
+// maxGameWindow with at least one match, or 0 if there is none. Without a
+// winning constraint every prefix of a match is also a match, so the answer
+// can be binary searched; otherwise every window is checked.
+func longestMatch(seqs [][]byte, minGameWindow, maxGameWindow, winningConstraint int, seed maphash.Seed) int {
+	if winningConstraint > 0 {
+		for gameWindow := maxGameWindow; gameWindow >= minGameWindow; gameWindow-- {
+			if hasMatch(seqs, gameWindow, winningConstraint, seed) {
+				return gameWindow
+			}
+		}
+		return 0
+	}
+	longest := 0
+	lo, hi := minGameWindow, maxGameWindow
+	for lo <= hi {
+		mid := (lo + hi) / 2
+		if hasMatch(seqs, mid, 0, seed) {
+			longest = mid
+			lo = mid + 1
+		} else {
+			hi = mid - 1
+		}
+	}
+	return longest
+}
+
+// simulateLongestMatches runs the Monte Carlo simulation and returns the
+// longest match found in each simulated dataset.
+func simulateLongestMatches(models []seasonModel, minGameWindow, maxGameWindow, winningConstraint, simulations int, seed int64) ([]int, error) {
+	var (
+		eg       errgroup.Group
+		mu       sync.Mutex
+		longest  = make([]int, simulations)
+		hashSeed = maphash.MakeSeed()
+	)
+	eg.SetLimit(runtime.NumCPU())
+	for i := 0; i < simulations; i++ {
+		i := i
+		eg.Go(func() error {
+			r := rand.New(rand.NewSource(seed + int64(i)))
+			l := longestMatch(simulate(models, r), minGameWindow, maxGameWindow, winningConstraint, hashSeed)
+			mu.Lock()
+			longest[i] = l
+			mu.Unlock()
+			return nil
+		})
+	}
+	return longest, eg.Wait()
+}
+
+// matchPValue is the fraction of simulated datasets with a match at least
+// gameWindow games long.
+func matchPValue(longest []int, gameWindow int) float64 {
+	if len(longest) == 0 {
+		return 1
+	}
+	var atLeast int
+	for _, l := range longest {
+		if l >= gameWindow {
+			atLeast++
+		}
+	}
+	return float64(atLeast) / float64(len(longest))
+}
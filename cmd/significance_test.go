@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"hash/maphash"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLongestMatch(t *testing.T) {
+	seed := maphash.MakeSeed()
+	tests := []struct {
+		name              string
+		seqs              []string
+		winningConstraint int
+		expected          int
+	}{
+		{
+			name:     "shared run across seasons",
+			seqs:     []string{"WLWWLLW", "LLWLWWLL"},
+			expected: 6,
+		},
+		{
+			name:     "repeat within one season",
+			seqs:     []string{"WWWWWW"},
+			expected: 5,
+		},
+		{
+			name:     "no match in range",
+			seqs:     []string{"WWTL", "LLTW"},
+			expected: 0,
+		},
+		{
+			name:              "winning constraint skips losing windows",
+			seqs:              []string{"LLLLWW", "LLLLWW"},
+			winningConstraint: 50,
+			expected:          4,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var seqs [][]byte
+			for _, seq := range test.seqs {
+				seqs = append(seqs, []byte(seq))
+			}
+			assert.Equal(t, test.expected, longestMatch(seqs, 2, 6, test.winningConstraint, seed))
+		})
+	}
+}
+
+func TestSeasonModelsFromRecords(t *testing.T) {
+	records := [][]string{
+		{"Year", "Team", "Game1", "Game2", "Game3", "Game4", "Game5"},
+		{"2000", "AAA", "W", "W", "L", "T", ""},
+		{"2000", "BBB", "W", "L", "L", "W", "W"},
+	}
+	schedule := homeSchedule{
+		{year: 2000, team: "BBB"}: {true, false, false, true, true},
+	}
+	models := seasonModelsFromRecords(records, schedule)
+	require.Len(t, models, 2)
+
+	assert.Equal(t, []float64{0.5, 0.5, 0.5, 0.5}, models[0].winProbs)
+	assert.Equal(t, []float64{0.25, 0.25, 0.25, 0.25}, models[0].tieProbs)
+	// BBB won all three home games and lost both road games.
+	assert.Equal(t, []float64{1, 0, 0, 1, 1}, models[1].winProbs)
+
+	seqs := simulate(models, rand.New(rand.NewSource(1)))
+	assert.Equal(t, "WLLWW", string(seqs[1]))
+}
+
+func TestMatchPValue(t *testing.T) {
+	longest := []int{0, 10, 12, 15}
+	assert.Equal(t, 0.75, matchPValue(longest, 10))
+	assert.Equal(t, 0.25, matchPValue(longest, 13))
+	assert.Equal(t, 0.0, matchPValue(longest, 16))
+}
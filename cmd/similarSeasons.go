@@ -0,0 +1,394 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// seasonFeatureNames names each entry of a season's feature vector. The
+// months run April through September; March games count toward April and
+// October games toward September.
+var seasonFeatureNames = []string{"apr", "may", "jun", "jul", "aug", "sep", "runDiff", "streakiness", "homeAway", "oneRun"}
+
+const firstFeatureMonth, lastFeatureMonth = time.April, time.September
+
+// decidedRuns returns the lengths of the season's runs of consecutive wins
+// or losses, in order. Ties are skipped without ending a run.
+func decidedRuns(games []TeamGame) []int {
+	var runs []int
+	var last Result
+	for _, game := range games {
+		if game.Result != Win && game.Result != Loss {
+			continue
+		}
+		if len(runs) > 0 && game.Result == last {
+			runs[len(runs)-1]++
+		} else {
+			runs = append(runs, 1)
+		}
+		last = game.Result
+	}
+	return runs
+}
+
+// Features describes the season as a vector, in the order of
+// seasonFeatureNames: winning percentage in each month, run differential
+// per game, streakiness as the average length of a run of wins or losses,
+// home winning percentage less road winning percentage, and winning
+// percentage in one-run games. A month without decided games takes the
+// season's winning percentage.
+func (s Season) Features() []float64 {
+	overall := s.GetSeasonRecord().Pct()
+	features := make([]float64, 0, len(seasonFeatureNames))
+	for month := firstFeatureMonth; month <= lastFeatureMonth; month++ {
+		month := month
+		record := s.RecordWhere(func(game TeamGame) bool {
+			m := game.Date.Month()
+			if m < firstFeatureMonth {
+				m = firstFeatureMonth
+			}
+			if m > lastFeatureMonth {
+				m = lastFeatureMonth
+			}
+			return m == month
+		})
+		if record.Wins+record.Losses == 0 {
+			features = append(features, overall)
+		} else {
+			features = append(features, record.Pct())
+		}
+	}
+
+	var differential int
+	for _, game := range s.Games {
+		differential += game.TeamScore - game.OpponentScore
+	}
+	var streakiness float64
+	if runs := decidedRuns(s.Games); len(runs) > 0 {
+		var decided int
+		for _, run := range runs {
+			decided += run
+		}
+		streakiness = float64(decided) / float64(len(runs))
+	}
+	home := s.RecordWhere(func(game TeamGame) bool { return game.IsHome }).Pct()
+	away := s.RecordWhere(func(game TeamGame) bool { return !game.IsHome }).Pct()
+	oneRun := s.RecordWhere(func(game TeamGame) bool {
+		return game.TeamScore-game.OpponentScore == 1 || game.OpponentScore-game.TeamScore == 1
+	}).Pct()
+
+	return append(features, ratio(differential, len(s.Games)), streakiness, home-away, oneRun)
+}
+
+// featureScaler standardizes feature vectors so each feature has mean 0 and
+// standard deviation 1 across every season, keeping run differential from
+// swamping winning percentages.
+type featureScaler struct {
+	mean   []float64
+	stdDev []float64
+}
+
+func newFeatureScaler(vectors [][]float64) featureScaler {
+	n := len(seasonFeatureNames)
+	fs := featureScaler{mean: make([]float64, n), stdDev: make([]float64, n)}
+	if len(vectors) == 0 {
+		return fs
+	}
+	for _, v := range vectors {
+		for i, x := range v {
+			fs.mean[i] += x
+		}
+	}
+	for i := range fs.mean {
+		fs.mean[i] /= float64(len(vectors))
+	}
+	for _, v := range vectors {
+		for i, x := range v {
+			fs.stdDev[i] += (x - fs.mean[i]) * (x - fs.mean[i])
+		}
+	}
+	for i := range fs.stdDev {
+		fs.stdDev[i] = math.Sqrt(fs.stdDev[i] / float64(len(vectors)))
+	}
+	return fs
+}
+
+func (fs featureScaler) scale(v []float64) []float64 {
+	scaled := make([]float64, len(v))
+	for i, x := range v {
+		if fs.stdDev[i] > 0 {
+			scaled[i] = (x - fs.mean[i]) / fs.stdDev[i]
+		}
+	}
+	return scaled
+}
+
+// distanceFunc measures how far apart two standardized feature vectors are,
+// each feature scaled by its weight. 0 is identical.
+type distanceFunc func(a, b, weights []float64) float64
+
+var distanceMetrics = map[string]distanceFunc{
+	"euclidean": func(a, b, weights []float64) float64 {
+		var sum float64
+		for i := range a {
+			d := weights[i] * (a[i] - b[i])
+			sum += d * d
+		}
+		return math.Sqrt(sum)
+	},
+	"manhattan": func(a, b, weights []float64) float64 {
+		var sum float64
+		for i := range a {
+			sum += math.Abs(weights[i] * (a[i] - b[i]))
+		}
+		return sum
+	},
+	// cosine is one minus the cosine similarity, so it compares the shape of
+	// two seasons rather than how extreme they were.
+	"cosine": func(a, b, weights []float64) float64 {
+		var dot, normA, normB float64
+		for i := range a {
+			wa, wb := weights[i]*a[i], weights[i]*b[i]
+			dot += wa * wb
+			normA += wa * wa
+			normB += wb * wb
+		}
+		if normA == 0 || normB == 0 {
+			return 1
+		}
+		return 1 - dot/math.Sqrt(normA*normB)
+	},
+}
+
+// parseFeatureWeights turns name=weight pairs into a weight for each
+// feature. Unnamed features weigh 1, and "months" sets all six months before
+// any single month named alongside it.
+func parseFeatureWeights(pairs map[string]string) ([]float64, error) {
+	weights := make([]float64, len(seasonFeatureNames))
+	for i := range weights {
+		weights[i] = 1
+	}
+	names := make([]string, 0, len(pairs))
+	for name := range pairs {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == "months") != (names[j] == "months") {
+			return names[i] == "months"
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		value := pairs[name]
+		weight, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("weight for %s: %w", name, err)
+		}
+		if weight < 0 {
+			return nil, fmt.Errorf("weight for %s is negative", name)
+		}
+		found := false
+		for i, feature := range seasonFeatureNames {
+			if feature == name || (name == "months" && i <= int(lastFeatureMonth-firstFeatureMonth)) {
+				weights[i] = weight
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown feature %q; features are months, %s", name, strings.Join(seasonFeatureNames, ", "))
+		}
+	}
+	return weights, nil
+}
+
+// FeatureGap compares one feature of two seasons. Gap is the weighted
+// difference in standard deviations.
+type FeatureGap struct {
+	Feature string
+	Target  float64
+	Other   float64
+	Gap     float64
+}
+
+// SimilarSeason is a season near the target, with its features ordered from
+// the most alike to the least.
+type SimilarSeason struct {
+	Franchise string
+	Team      string
+	Year      int
+	Record    SeasonRecord
+	Distance  float64
+	Gaps      []FeatureGap
+}
+
+// similarSeasons ranks the seasons nearest the target. Seasons shorter than
+// minGames are left out, as is the target itself.
+func similarSeasons(target *Season, seasons []*Season, weights []float64, distance distanceFunc, minGames, top int) []SimilarSeason {
+	var candidates []*Season
+	var vectors [][]float64
+	for _, season := range seasons {
+		if len(season.Games) < minGames {
+			continue
+		}
+		candidates = append(candidates, season)
+		vectors = append(vectors, season.Features())
+	}
+	scaler := newFeatureScaler(vectors)
+	targetRaw := target.Features()
+	targetScaled := scaler.scale(targetRaw)
+
+	var similar []SimilarSeason
+	for i, season := range candidates {
+		if season.Franchise == target.Franchise && season.Year == target.Year {
+			continue
+		}
+		scaled := scaler.scale(vectors[i])
+		ss := SimilarSeason{
+			Franchise: season.Franchise,
+			Team:      season.Team,
+			Year:      season.Year,
+			Record:    season.GetSeasonRecord(),
+			Distance:  distance(targetScaled, scaled, weights),
+		}
+		for j, feature := range seasonFeatureNames {
+			ss.Gaps = append(ss.Gaps, FeatureGap{
+				Feature: feature,
+				Target:  targetRaw[j],
+				Other:   vectors[i][j],
+				Gap:     math.Abs(weights[j] * (targetScaled[j] - scaled[j])),
+			})
+		}
+		sort.SliceStable(ss.Gaps, func(a, b int) bool { return ss.Gaps[a].Gap < ss.Gaps[b].Gap })
+		similar = append(similar, ss)
+	}
+	sort.Slice(similar, func(i, j int) bool {
+		if similar[i].Distance != similar[j].Distance {
+			return similar[i].Distance < similar[j].Distance
+		}
+		if similar[i].Year != similar[j].Year {
+			return similar[i].Year < similar[j].Year
+		}
+		return similar[i].Franchise < similar[j].Franchise
+	})
+	if len(similar) > top {
+		similar = similar[:top]
+	}
+	return similar
+}
+
+func formatFeatureGaps(gaps []FeatureGap) string {
+	parts := make([]string, len(gaps))
+	for i, gap := range gaps {
+		parts[i] = fmt.Sprintf("%s %.3f vs %.3f", gap.Feature, gap.Target, gap.Other)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// similarSeasonsCmd represents the similarSeasons command
+var similarSeasonsCmd = &cobra.Command{
+	Use:   "similarSeasons",
+	Short: "Find the seasons most like a given team-season",
+	Long: `Describes every season as a vector of features and lists the seasons nearest the given one.
+The features are winning percentage in each month from April to September (March games count
+toward April and October toward September), run differential per game, streakiness (the
+average length of a run of wins or losses), home winning percentage less road winning
+percentage, and winning percentage in one-run games. Each feature is standardized across every
+season before distances are measured. Each match lists the features it shares most closely with
+the target first.
+
+Inputs:
+
+franchise, year: The season to match, e.g. SFN and 2021.
+weights: Feature weights as name=weight pairs, e.g. runDiff=2,months=0.5. Features not named
+weigh 1. Names: months, apr, may, jun, jul, aug, sep, runDiff, streakiness, homeAway, oneRun.
+metric: "euclidean", "manhattan" or "cosine".
+min-games: The fewest games a season must have to be considered.
+top: How many seasons to list.
+format: "text" or "json".
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		franchise, err := cmd.Flags().GetString("franchise")
+		if err != nil {
+			return err
+		}
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+		weightPairs, err := cmd.Flags().GetStringToString("weights")
+		if err != nil {
+			return err
+		}
+		weights, err := parseFeatureWeights(weightPairs)
+		if err != nil {
+			return err
+		}
+		metric, err := cmd.Flags().GetString("metric")
+		if err != nil {
+			return err
+		}
+		distance, ok := distanceMetrics[metric]
+		if !ok {
+			return fmt.Errorf("unknown metric %q", metric)
+		}
+		minGames, err := cmd.Flags().GetInt("min-games")
+		if err != nil {
+			return err
+		}
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown format %q", format)
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		target, ok := teamsBySeason.m[franchise][year]
+		if !ok {
+			return fmt.Errorf("no games found for %s in %d", franchise, year)
+		}
+		var seasons []*Season
+		for _, franchiseSeasons := range teamsBySeason.BySortedSeason() {
+			seasons = append(seasons, franchiseSeasons...)
+		}
+		similar := similarSeasons(target, seasons, weights, distance, minGames, top)
+		if format == "json" {
+			return writeJSON(similar)
+		}
+		for _, ss := range similar {
+			fmt.Printf("%d %s\t%s\t%.3f\n", ss.Year, ss.Team, ss.Record, ss.Distance)
+			fmt.Printf("  closest: %s\n", formatFeatureGaps(ss.Gaps[:3]))
+			fmt.Printf("  furthest: %s\n", formatFeatureGaps(ss.Gaps[len(ss.Gaps)-2:]))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(similarSeasonsCmd)
+	similarSeasonsCmd.Flags().String("franchise", "", "franchise of the season to match")
+	similarSeasonsCmd.Flags().Int("year", 0, "season to match")
+	similarSeasonsCmd.Flags().StringToString("weights", nil, "feature weights as name=weight pairs")
+	similarSeasonsCmd.Flags().String("metric", "euclidean", "distance metric: euclidean, manhattan or cosine")
+	similarSeasonsCmd.Flags().Int("min-games", 100, "fewest games for a season to be considered")
+	similarSeasonsCmd.Flags().Int("top", 10, "number of seasons to list")
+	similarSeasonsCmd.Flags().String("format", "text", "output format: text or json")
+	similarSeasonsCmd.MarkFlagRequired("franchise")
+	similarSeasonsCmd.MarkFlagRequired("year")
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecidedRuns(t *testing.T) {
+	season := testSeason("SFN", 2021, "NL", Win, Win, Tie, Win, Loss, Loss, Win)
+	assert.Equal(t, []int{3, 2, 1}, decidedRuns(season.Games))
+	assert.Empty(t, decidedRuns(nil))
+}
+
+func TestSeasonFeatures(t *testing.T) {
+	season := testSeason("SFN", 2021, "NL", Win, Win, Loss, Win)
+	scores := [][2]int{{5, 4}, {8, 0}, {1, 2}, {3, 2}}
+	for i, score := range scores {
+		season.Games[i].TeamScore, season.Games[i].OpponentScore = score[0], score[1]
+		season.Games[i].IsHome = i%2 == 0
+	}
+	// A March game counts toward April, and an October game toward September.
+	season.Games[0].Date = time.Date(2021, time.March, 31, 0, 0, 0, 0, time.UTC)
+	season.Games[3].Date = time.Date(2021, time.October, 2, 0, 0, 0, 0, time.UTC)
+
+	features := season.Features()
+	require.Len(t, features, len(seasonFeatureNames))
+	assert.InDelta(t, 2.0/3, features[0], 1e-9) // apr
+	assert.InDelta(t, .75, features[1], 1e-9)   // may, no games
+	assert.InDelta(t, 1, features[5], 1e-9)     // sep
+	assert.InDelta(t, 2.25, features[6], 1e-9)  // runDiff
+	assert.InDelta(t, 4.0/3, features[7], 1e-9) // streakiness
+	assert.InDelta(t, -.5, features[8], 1e-9)   // homeAway
+	assert.InDelta(t, 2.0/3, features[9], 1e-9) // oneRun
+}
+
+func TestParseFeatureWeights(t *testing.T) {
+	weights, err := parseFeatureWeights(map[string]string{"months": "0.5", "runDiff": "3"})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{.5, .5, .5, .5, .5, .5, 3, 1, 1, 1}, weights)
+
+	// A named month overrides "months" whichever order the map yields them in.
+	for i := 0; i < 20; i++ {
+		weights, err = parseFeatureWeights(map[string]string{"months": "0.5", "apr": "2"})
+		require.NoError(t, err)
+		assert.Equal(t, []float64{2, .5, .5, .5, .5, .5, 1, 1, 1, 1}, weights)
+	}
+
+	_, err = parseFeatureWeights(map[string]string{"luck": "1"})
+	assert.Error(t, err)
+	_, err = parseFeatureWeights(map[string]string{"oneRun": "-1"})
+	assert.Error(t, err)
+	_, err = parseFeatureWeights(map[string]string{"oneRun": "x"})
+	assert.Error(t, err)
+}
+
+func TestDistanceMetrics(t *testing.T) {
+	a := []float64{1, 2}
+	b := []float64{4, 6}
+	weights := []float64{1, 1}
+	assert.InDelta(t, 5, distanceMetrics["euclidean"](a, b, weights), 1e-9)
+	assert.InDelta(t, 7, distanceMetrics["manhattan"](a, b, weights), 1e-9)
+	assert.InDelta(t, 6, distanceMetrics["manhattan"](a, b, []float64{0, 1.5}), 1e-9)
+	assert.InDelta(t, 0, distanceMetrics["cosine"](a, []float64{2, 4}, weights), 1e-9)
+	assert.InDelta(t, 1, distanceMetrics["cosine"]([]float64{1, 0}, []float64{0, 1}, weights), 1e-9)
+}
+
+func TestSimilarSeasons(t *testing.T) {
+	target := testSeason("SFN", 2021, "NL", Win, Win, Win, Loss, Win, Loss)
+	twin := testSeason("NYA", 1998, "AL", Win, Win, Win, Loss, Win, Loss)
+	near := testSeason("SEA", 2001, "AL", Win, Win, Loss, Win, Win, Loss)
+	far := testSeason("DET", 2003, "AL", Loss, Loss, Loss, Loss, Win, Loss)
+	short := testSeason("BRO", 1900, "NL", Win, Win, Win, Loss, Win)
+	seasons := []*Season{target, far, near, twin, short}
+
+	weights, err := parseFeatureWeights(nil)
+	require.NoError(t, err)
+	similar := similarSeasons(target, seasons, weights, distanceMetrics["euclidean"], 6, 10)
+	require.Len(t, similar, 3)
+	assert.Equal(t, "NYA", similar[0].Franchise)
+	assert.Zero(t, similar[0].Distance)
+	assert.Equal(t, "SEA", similar[1].Franchise)
+	assert.Equal(t, "DET", similar[2].Franchise)
+	assert.Equal(t, SeasonRecord{Wins: 1, Losses: 5}, similar[2].Record)
+	require.Len(t, similar[1].Gaps, len(seasonFeatureNames))
+	assert.LessOrEqual(t, similar[1].Gaps[0].Gap, similar[1].Gaps[len(similar[1].Gaps)-1].Gap)
+
+	assert.Len(t, similarSeasons(target, seasons, weights, distanceMetrics["euclidean"], 6, 1), 1)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// WindowProfile summarizes how a team played over a run of its games: how
+// many runs it scored and allowed, how many games were decided by one run
+// and how many it played at home.
+type WindowProfile struct {
+	Team        string
+	Year        int
+	FirstGame   int
+	LastGame    int
+	Games       int
+	RunsScored  int
+	RunsAllowed int
+	OneRunGames int
+	HomeGames   int
+}
+
+// windowProfile profiles the season's games first through last, counted from
+// 1. The window is cut short if the game logs have fewer games.
+func windowProfile(season *Season, first, last int) WindowProfile {
+	wp := WindowProfile{Team: season.Team, Year: season.Year, FirstGame: first, LastGame: last}
+	for i := first - 1; i < last && i < len(season.Games); i++ {
+		game := season.Games[i]
+		wp.Games++
+		wp.RunsScored += game.TeamScore
+		wp.RunsAllowed += game.OpponentScore
+		if game.TeamScore-game.OpponentScore == 1 || game.OpponentScore-game.TeamScore == 1 {
+			wp.OneRunGames++
+		}
+		if game.IsHome {
+			wp.HomeGames++
+		}
+	}
+	return wp
+}
+
+func (wp WindowProfile) RunsScoredPerGame() float64  { return ratio(wp.RunsScored, wp.Games) }
+func (wp WindowProfile) RunsAllowedPerGame() float64 { return ratio(wp.RunsAllowed, wp.Games) }
+func (wp WindowProfile) OneRunShare() float64        { return ratio(wp.OneRunGames, wp.Games) }
+func (wp WindowProfile) HomeShare() float64          { return ratio(wp.HomeGames, wp.Games) }
+
+func (wp WindowProfile) RunDifferentialPerGame() float64 {
+	return ratio(wp.RunsScored-wp.RunsAllowed, wp.Games)
+}
+
+// similarityRunScale is the gap in runs per game treated as completely
+// dissimilar.
+const similarityRunScale = 2.0
+
+// WindowSimilarity contrasts two windows with identical W/L sequences. Each
+// difference is scaled to between 0 and 1, and Score is one minus their
+// average: 1 for windows that played out identically, 0 for windows that
+// share nothing but their results.
+type WindowSimilarity struct {
+	A, B            WindowProfile
+	RunsScoredDiff  float64
+	RunsAllowedDiff float64
+	RunDiffDiff     float64
+	OneRunShareDiff float64
+	HomeShareDiff   float64
+	Score           float64
+}
+
+func compareWindows(a, b WindowProfile) WindowSimilarity {
+	ws := WindowSimilarity{
+		A:               a,
+		B:               b,
+		RunsScoredDiff:  math.Abs(a.RunsScoredPerGame() - b.RunsScoredPerGame()),
+		RunsAllowedDiff: math.Abs(a.RunsAllowedPerGame() - b.RunsAllowedPerGame()),
+		RunDiffDiff:     math.Abs(a.RunDifferentialPerGame() - b.RunDifferentialPerGame()),
+		OneRunShareDiff: math.Abs(a.OneRunShare() - b.OneRunShare()),
+		HomeShareDiff:   math.Abs(a.HomeShare() - b.HomeShare()),
+	}
+	scaled := []float64{
+		math.Min(ws.RunsScoredDiff/similarityRunScale, 1),
+		math.Min(ws.RunsAllowedDiff/similarityRunScale, 1),
+		math.Min(ws.RunDiffDiff/similarityRunScale, 1),
+		ws.OneRunShareDiff,
+		ws.HomeShareDiff,
+	}
+	var total float64
+	for _, d := range scaled {
+		total += d
+	}
+	ws.Score = 1 - total/float64(len(scaled))
+	return ws
+}
+
+// matchSimilarities profiles every window of a match from the game logs and
+// compares each pair. Windows from seasons missing from the game logs are
+// skipped.
+func matchSimilarities(details []seasonDetails, seasons map[seasonKey]*Season) []WindowSimilarity {
+	var profiles []WindowProfile
+	for _, detail := range details {
+		year, err := strconv.Atoi(detail.season)
+		if err != nil {
+			continue
+		}
+		season, ok := seasons[seasonKey{year: year, team: detail.team}]
+		if !ok {
+			continue
+		}
+		profiles = append(profiles, windowProfile(season, detail.gameStart, detail.gameEnd))
+	}
+	var similarities []WindowSimilarity
+	for i := range profiles {
+		for j := i + 1; j < len(profiles); j++ {
+			similarities = append(similarities, compareWindows(profiles[i], profiles[j]))
+		}
+	}
+	return similarities
+}
+
+// seasonsByTeam indexes every season by year and the team code the transform
+// command writes.
+func seasonsByTeam(btbs *ByTeamsBySeason) map[seasonKey]*Season {
+	seasons := make(map[seasonKey]*Season)
+	for _, franchiseSeasons := range btbs.BySortedSeason() {
+		for _, season := range franchiseSeasons {
+			seasons[seasonKey{year: season.Year, team: season.Team}] = season
+		}
+	}
+	return seasons
+}
+
+func printSimilarity(ws WindowSimilarity) {
+	profile := func(wp WindowProfile) string {
+		return fmt.Sprintf("%d %s games %d-%d: %.2f R/G, %.2f RA/G, %+.2f diff/G, %d one-run, %d home",
+			wp.Year, wp.Team, wp.FirstGame, wp.LastGame, wp.RunsScoredPerGame(), wp.RunsAllowedPerGame(),
+			wp.RunDifferentialPerGame(), wp.OneRunGames, wp.HomeGames)
+	}
+	fmt.Printf("  Similarity %.2f\n    %s\n    %s\n", ws.Score, profile(ws.A), profile(ws.B))
+}
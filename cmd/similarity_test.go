@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowProfile(t *testing.T) {
+	season := testSeason("SFN", 2021, "NL", Win, Loss, Win, Win)
+	scores := [][2]int{{5, 4}, {1, 7}, {3, 2}, {10, 0}}
+	for i, score := range scores {
+		season.Games[i].TeamScore, season.Games[i].OpponentScore = score[0], score[1]
+		season.Games[i].IsHome = i < 2
+	}
+
+	assert.Equal(t, WindowProfile{
+		Team: "SFN", Year: 2021, FirstGame: 2, LastGame: 4,
+		Games: 3, RunsScored: 14, RunsAllowed: 9, OneRunGames: 1, HomeGames: 1,
+	}, windowProfile(season, 2, 4))
+
+	// Windows running past the end of the game logs are cut short.
+	wp := windowProfile(season, 3, 10)
+	assert.Equal(t, 2, wp.Games)
+	assert.InDelta(t, 5.5, wp.RunDifferentialPerGame(), 1e-9)
+}
+
+func TestCompareWindows(t *testing.T) {
+	a := WindowProfile{Games: 10, RunsScored: 50, RunsAllowed: 40, OneRunGames: 3, HomeGames: 5}
+	assert.Equal(t, 1.0, compareWindows(a, a).Score)
+
+	b := WindowProfile{Games: 10, RunsScored: 40, RunsAllowed: 40, OneRunGames: 1, HomeGames: 7}
+	ws := compareWindows(a, b)
+	assert.InDelta(t, 1, ws.RunsScoredDiff, 1e-9)
+	assert.InDelta(t, 0, ws.RunsAllowedDiff, 1e-9)
+	assert.InDelta(t, 1, ws.RunDiffDiff, 1e-9)
+	assert.InDelta(t, .2, ws.OneRunShareDiff, 1e-9)
+	assert.InDelta(t, .2, ws.HomeShareDiff, 1e-9)
+	assert.InDelta(t, 1-(.5+0+.5+.2+.2)/5, ws.Score, 1e-9)
+
+	// Gaps in scoring beyond the scale count as completely dissimilar.
+	c := WindowProfile{Games: 10, RunsScored: 150, RunsAllowed: 0, OneRunGames: 3, HomeGames: 5}
+	assert.InDelta(t, 1-3.0/5, compareWindows(a, c).Score, 1e-9)
+}
+
+func TestMatchSimilarities(t *testing.T) {
+	teamsBySeason, err := GetTeamsBySeason("./test_data")
+	require.NoError(t, err)
+	seasons := seasonsByTeam(teamsBySeason)
+
+	details := []seasonDetails{
+		{season: "2000", team: "SFN", length: 2, gameStart: 1, gameEnd: 2},
+		{season: "2001", team: "SFN", length: 2, gameStart: 1, gameEnd: 2},
+		{season: "1900", team: "SFN", length: 2, gameStart: 1, gameEnd: 2},
+	}
+	similarities := matchSimilarities(details, seasons)
+	require.Len(t, similarities, 1)
+	assert.Equal(t, 2000, similarities[0].A.Year)
+	assert.Equal(t, 2001, similarities[0].B.Year)
+	assert.Equal(t, 5, similarities[0].A.RunsScored)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// blowoutMargin is the smallest run margin that makes a game a blowout.
+const blowoutMargin = 5
+
+// Split is a season's record in a subset of its games.
+type Split struct {
+	Name   string
+	Record SeasonRecord
+}
+
+func (sr SeasonRecord) Pct() float64 {
+	if sr.Wins+sr.Losses == 0 {
+		return 0
+	}
+	return float64(sr.Wins) / float64(sr.Wins+sr.Losses)
+}
+
+// Splits breaks a season's record down by where, when and against whom its
+// games were played. The division split is left out for seasons played
+// without divisions, and games of unknown length never count as extra innings.
+func (s Season) Splits(directory TeamDirectory) []Split {
+	margin := func(game TeamGame) int {
+		if game.TeamScore > game.OpponentScore {
+			return game.TeamScore - game.OpponentScore
+		}
+		return game.OpponentScore - game.TeamScore
+	}
+	split := func(name string, keep func(TeamGame) bool) Split {
+		return Split{Name: name, Record: s.RecordWhere(keep)}
+	}
+
+	splits := []Split{
+		split("Home", func(game TeamGame) bool { return game.IsHome }),
+		split("Away", func(game TeamGame) bool { return !game.IsHome }),
+		split("Day", func(game TeamGame) bool { return game.DayNight == Day }),
+		split("Night", func(game TeamGame) bool { return game.DayNight == Night }),
+	}
+
+	leagues := make(map[string]struct{})
+	for _, game := range s.Games {
+		leagues[game.OpponentLeague] = struct{}{}
+	}
+	var leagueNames []string
+	for league := range leagues {
+		leagueNames = append(leagueNames, league)
+	}
+	sort.Strings(leagueNames)
+	for _, league := range leagueNames {
+		league := league
+		splits = append(splits, split("vs "+league, func(game TeamGame) bool { return game.OpponentLeague == league }))
+	}
+
+	placement := seasonPlacement(&s, directory)
+	if placement.Division != "" {
+		splits = append(splits, split("vs "+divisionName(placement.League, placement.Division), func(game TeamGame) bool {
+			opponent, ok := directory.Lookup(game.OpponentTeam, game.Date)
+			return ok && opponent.League == placement.League && opponent.Division == placement.Division
+		}))
+	}
+
+	return append(splits,
+		split("One-run games", func(game TeamGame) bool { return margin(game) == 1 }),
+		split("Extra innings", func(game TeamGame) bool { return game.ExtraInnings() }),
+		split(fmt.Sprintf("Blowouts (%d+ runs)", blowoutMargin), func(game TeamGame) bool { return margin(game) >= blowoutMargin }),
+	)
+}
+
+// splitsCmd represents the splits command
+var splitsCmd = &cobra.Command{
+	Use:   "splits",
+	Short: "Split records for a team-season",
+	Long: `Breaks a franchise's season record down into home and away, day and night, against each
+league and its own division, one-run games, extra-inning games and blowouts.
+
+Inputs:
+
+franchise: The franchise's Retrosheet code, e.g. SFN.
+year: The season.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		franchise, err := cmd.Flags().GetString("franchise")
+		if err != nil {
+			return err
+		}
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, directory, err := loadStandingsData(rsDataDir)
+		if err != nil {
+			return err
+		}
+		season, ok := teamsBySeason.m[franchise][year]
+		if !ok {
+			return fmt.Errorf("no games found for %s in %d", franchise, year)
+		}
+
+		fmt.Printf("%s %d\n", season.Team, season.Year)
+		fmt.Println("Split\tRecord\tPct")
+		overall := season.GetSeasonRecord()
+		fmt.Printf("Overall\t%s\t%s\n", overall, formatPct(overall.Pct()))
+		for _, split := range season.Splits(directory) {
+			fmt.Printf("%s\t%s\t%s\n", split.Name, split.Record, formatPct(split.Record.Pct()))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(splitsCmd)
+	splitsCmd.Flags().String("franchise", "", "franchise to report on")
+	splitsCmd.Flags().Int("year", 0, "season to report on")
+	splitsCmd.MarkFlagRequired("franchise")
+	splitsCmd.MarkFlagRequired("year")
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamGameExtraInnings(t *testing.T) {
+	tests := []struct {
+		name       string
+		date       time.Time
+		gameNumber int
+		outs       int
+		expected   bool
+	}{
+		{name: "nine innings", date: time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC), outs: 54},
+		{name: "ten innings", date: time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC), outs: 60, expected: true},
+		{name: "seven inning doubleheader", date: time.Date(2021, time.May, 1, 0, 0, 0, 0, time.UTC), gameNumber: 1, outs: 42},
+		{name: "eight inning doubleheader", date: time.Date(2020, time.August, 1, 0, 0, 0, 0, time.UTC), gameNumber: 2, outs: 48, expected: true},
+		{name: "nine inning doubleheader before 2020", date: time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC), gameNumber: 1, outs: 48},
+		{name: "unknown length", date: time.Date(1901, time.May, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		game := TeamGame{Date: tt.date, GameNumber: tt.gameNumber, LengthInOuts: tt.outs}
+		assert.Equal(t, tt.expected, game.ExtraInnings(), tt.name)
+	}
+}
+
+func TestSeasonSplits(t *testing.T) {
+	directory, err := getTeamDirectory("./rs_data/misc/CurrentNames.csv")
+	require.NoError(t, err)
+
+	game := func(day int, opponent, opponentLeague string, isHome bool, dayNight DayNight, teamScore, opponentScore, outs int) TeamGame {
+		result := Win
+		if teamScore < opponentScore {
+			result = Loss
+		}
+		return TeamGame{
+			Date:           time.Date(2021, time.May, day, 0, 0, 0, 0, time.UTC),
+			Team:           "SFN",
+			Franchise:      "SFN",
+			League:         "NL",
+			OpponentTeam:   opponent,
+			OpponentLeague: opponentLeague,
+			TeamGameNumber: day,
+			IsHome:         isHome,
+			DayNight:       dayNight,
+			LengthInOuts:   outs,
+			TeamScore:      teamScore,
+			OpponentScore:  opponentScore,
+			Result:         result,
+		}
+	}
+	season := Season{Franchise: "SFN", Team: "SFN", Year: 2021, Games: []TeamGame{
+		game(1, "LAN", "NL", true, Night, 3, 2, 51),
+		game(2, "LAN", "NL", true, Day, 1, 8, 54),
+		game(3, "NYN", "NL", false, Night, 5, 4, 60),
+		game(4, "OAK", "AL", false, Night, 9, 1, 54),
+		game(5, "OAK", "AL", false, UnknownDayNight, 2, 3, 0),
+	}}
+
+	records := make(map[string]string)
+	for _, split := range season.Splits(directory) {
+		records[split.Name] = split.Record.String()
+	}
+	assert.Equal(t, map[string]string{
+		"Home":               "1-1",
+		"Away":               "2-1",
+		"Day":                "0-1",
+		"Night":              "3-0",
+		"vs AL":              "1-1",
+		"vs NL":              "2-1",
+		"vs NL West":         "1-1",
+		"One-run games":      "2-1",
+		"Extra innings":      "1-0",
+		"Blowouts (5+ runs)": "1-1",
+	}, records)
+}
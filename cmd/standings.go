@@ -0,0 +1,347 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type StandingsEntry struct {
+	Team        string
+	Franchise   string
+	Wins        int
+	Losses      int
+	Ties        int
+	GamesBehind float64
+
+	// GamesRemaining is the number of games the team still had to play,
+	// based on the games it actually played that season.
+	GamesRemaining int
+}
+
+// Pct is the winning percentage, ignoring ties.
+func (se StandingsEntry) Pct() float64 {
+	if se.Wins+se.Losses == 0 {
+		return 0
+	}
+	return float64(se.Wins) / float64(se.Wins+se.Losses)
+}
+
+type StandingsTable struct {
+	League   string
+	Division string
+	Entries  []StandingsEntry // sorted by winning percentage
+}
+
+func (st StandingsTable) Name() string {
+	return divisionName(st.League, st.Division)
+}
+
+type Standings struct {
+	Date   time.Time
+	Tables []StandingsTable
+}
+
+// standingsEngine reconstructs league and division tables for a single year
+// from every franchise's season.
+type standingsEngine struct {
+	seasons    []*Season
+	placements []TeamInfo // league and division of seasons[i]
+}
+
+func newStandingsEngine(seasons []*Season, directory TeamDirectory) *standingsEngine {
+	se := &standingsEngine{seasons: seasons}
+	for _, season := range seasons {
+		se.placements = append(se.placements, seasonPlacement(season, directory))
+	}
+	return se
+}
+
+// seasonPlacement finds the league and division a season was played in. Teams
+// missing from CurrentNames.csv (defunct clubs and leagues) fall back to the
+// league recorded in the game log, with no division.
+func seasonPlacement(season *Season, directory TeamDirectory) TeamInfo {
+	if len(season.Games) == 0 {
+		return TeamInfo{Team: season.Team, Franchise: season.Franchise}
+	}
+	if info, ok := directory.Lookup(season.Team, season.Games[0].Date); ok {
+		return info
+	}
+	return TeamInfo{
+		Team:      season.Team,
+		Franchise: season.Franchise,
+		League:    season.Games[0].League,
+	}
+}
+
+// Dates returns every date on which at least one game ended, in order.
+func (se *standingsEngine) Dates() []time.Time {
+	seen := make(map[time.Time]struct{})
+	var dates []time.Time
+	for _, season := range se.seasons {
+		for _, game := range season.Games {
+			if _, ok := seen[game.ResultDate]; ok {
+				continue
+			}
+			seen[game.ResultDate] = struct{}{}
+			dates = append(dates, game.ResultDate)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates
+}
+
+// On returns the standings after every game that ended on or before date. A
+// suspended game counts on the day it was completed.
+func (se *standingsEngine) On(date time.Time) Standings {
+	tables := make(map[TeamInfo]*StandingsTable)
+	var keys []TeamInfo
+	for i, season := range se.seasons {
+		key := TeamInfo{League: se.placements[i].League, Division: se.placements[i].Division}
+		table, ok := tables[key]
+		if !ok {
+			table = &StandingsTable{League: key.League, Division: key.Division}
+			tables[key] = table
+			keys = append(keys, key)
+		}
+		entry := StandingsEntry{Team: season.Team, Franchise: season.Franchise}
+		for _, game := range season.Games {
+			if game.ResultDate.After(date) {
+				entry.GamesRemaining++
+				continue
+			}
+			switch game.Result {
+			case Win:
+				entry.Wins++
+			case Loss:
+				entry.Losses++
+			case Tie:
+				entry.Ties++
+			}
+		}
+		table.Entries = append(table.Entries, entry)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].League != keys[j].League {
+			return keys[i].League < keys[j].League
+		}
+		return divisionOrder[keys[i].Division] < divisionOrder[keys[j].Division]
+	})
+
+	standings := Standings{Date: date}
+	for _, key := range keys {
+		table := tables[key]
+		rankEntries(table.Entries)
+		standings.Tables = append(standings.Tables, *table)
+	}
+	return standings
+}
+
+// Timeline returns the standings at the end of every date with games.
+func (se *standingsEngine) Timeline() []Standings {
+	var timeline []Standings
+	for _, date := range se.Dates() {
+		timeline = append(timeline, se.On(date))
+	}
+	return timeline
+}
+
+// rankEntries sorts entries by winning percentage and computes games behind
+// the leader.
+func rankEntries(entries []StandingsEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Pct() != entries[j].Pct() {
+			return entries[i].Pct() > entries[j].Pct()
+		}
+		if entries[i].Wins != entries[j].Wins {
+			return entries[i].Wins > entries[j].Wins
+		}
+		return entries[i].Team < entries[j].Team
+	})
+	if len(entries) == 0 {
+		return
+	}
+	leader := entries[0]
+	for i := range entries {
+		entries[i].GamesBehind = gamesBehind(leader, entries[i])
+	}
+}
+
+func gamesBehind(leader, team StandingsEntry) float64 {
+	return float64((leader.Wins-team.Wins)+(team.Losses-leader.Losses)) / 2
+}
+
+var divisionOrder = map[string]int{"E": 1, "C": 2, "W": 3}
+
+func divisionName(league, division string) string {
+	switch division {
+	case "E":
+		return league + " East"
+	case "C":
+		return league + " Central"
+	case "W":
+		return league + " West"
+	case "":
+		return league
+	}
+	return league + " " + division
+}
+
+func formatPct(pct float64) string {
+	return strings.TrimPrefix(fmt.Sprintf("%.3f", pct), "0")
+}
+
+func formatGamesBehind(gb float64) string {
+	if gb == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(gb, 'f', 1, 64)
+}
+
+func printStandings(standings Standings) {
+	fmt.Println(standings.Date.Format("2006-01-02"))
+	for _, table := range standings.Tables {
+		fmt.Println(table.Name())
+		fmt.Printf("%-5s %4s %4s %4s %6s %6s\n", "Team", "W", "L", "T", "Pct", "GB")
+		for _, entry := range table.Entries {
+			fmt.Printf("%-5s %4d %4d %4d %6s %6s\n", entry.Team, entry.Wins, entry.Losses, entry.Ties, formatPct(entry.Pct()), formatGamesBehind(entry.GamesBehind))
+		}
+		fmt.Println()
+	}
+}
+
+func writeStandingsCSV(csvWriter *csv.Writer, standings Standings) error {
+	for _, table := range standings.Tables {
+		for _, entry := range table.Entries {
+			err := csvWriter.Write([]string{
+				standings.Date.Format("2006-01-02"),
+				table.League,
+				table.Division,
+				entry.Team,
+				entry.Franchise,
+				strconv.Itoa(entry.Wins),
+				strconv.Itoa(entry.Losses),
+				strconv.Itoa(entry.Ties),
+				strconv.FormatFloat(entry.Pct(), 'f', 3, 64),
+				strconv.FormatFloat(entry.GamesBehind, 'f', 1, 64),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadStandingsData reads the Retrosheet game logs along with the team
+// directory needed to place each season in a league and division.
+func loadStandingsData(dataDir string) (*ByTeamsBySeason, TeamDirectory, error) {
+	directory, err := getTeamDirectory(filepath.Join(dataDir, "misc/CurrentNames.csv"))
+	if err != nil {
+		return nil, nil, err
+	}
+	teamsBySeason, err := GetTeamsBySeason(dataDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return teamsBySeason, directory, nil
+}
+
+func standingsEngineForYear(teamsBySeason *ByTeamsBySeason, directory TeamDirectory, year int) (*standingsEngine, error) {
+	seasons := teamsBySeason.SeasonsInYear(year)
+	if len(seasons) == 0 {
+		return nil, fmt.Errorf("no games found for %d", year)
+	}
+	return newStandingsEngine(seasons, directory), nil
+}
+
+// standingsCmd represents the standings command
+var standingsCmd = &cobra.Command{
+	Use:   "standings",
+	Short: "Reconstruct standings for a season",
+	Long: `Reconstructs each league and division table (W-L, pct and games behind) from the
+Retrosheet game logs, using the league and division assignments in CurrentNames.csv.
+
+Inputs:
+
+year: The season to reconstruct.
+date: Only print the standings at the end of this date (YYYY-MM-DD). Without it, the
+standings after every date with games are printed as a timeline.
+format: "text" for printed tables or "csv" for one row per team per date.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+		dateFlag, err := cmd.Flags().GetString("date")
+		if err != nil {
+			return err
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != "text" && format != "csv" {
+			return fmt.Errorf("unknown format %q", format)
+		}
+
+		teamsBySeason, directory, err := loadStandingsData(rsDataDir)
+		if err != nil {
+			return err
+		}
+		engine, err := standingsEngineForYear(teamsBySeason, directory, year)
+		if err != nil {
+			return err
+		}
+
+		var timeline []Standings
+		if dateFlag != "" {
+			date, err := time.Parse("2006-01-02", dateFlag)
+			if err != nil {
+				return err
+			}
+			timeline = []Standings{engine.On(date)}
+		} else {
+			timeline = engine.Timeline()
+		}
+
+		if format == "text" {
+			for _, standings := range timeline {
+				printStandings(standings)
+			}
+			return nil
+		}
+
+		csvWriter := csv.NewWriter(os.Stdout)
+		headers := []string{"Date", "League", "Division", "Team", "Franchise", "Wins", "Losses", "Ties", "Pct", "GamesBehind"}
+		if err := csvWriter.Write(headers); err != nil {
+			return err
+		}
+		for _, standings := range timeline {
+			if err := writeStandingsCSV(csvWriter, standings); err != nil {
+				return err
+			}
+		}
+		csvWriter.Flush()
+		return csvWriter.Error()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(standingsCmd)
+	standingsCmd.Flags().Int("year", 0, "season to reconstruct")
+	standingsCmd.Flags().String("date", "", "only show standings at the end of this date (YYYY-MM-DD)")
+	standingsCmd.Flags().String("format", "text", "output format: text or csv")
+	standingsCmd.MarkFlagRequired("year")
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSeason(franchise string, year int, league string, results ...Result) *Season {
+	season := &Season{Franchise: franchise, Team: franchise, Year: year}
+	for i, result := range results {
+		date := time.Date(year, time.April, i+1, 0, 0, 0, 0, time.UTC)
+		season.Games = append(season.Games, TeamGame{
+			Date:           date,
+			ResultDate:     date,
+			Team:           franchise,
+			Franchise:      franchise,
+			League:         league,
+			TeamGameNumber: i + 1,
+			Result:         result,
+		})
+	}
+	return season
+}
+
+func TestTeamDirectoryLookup(t *testing.T) {
+	directory, err := getTeamDirectory("./rs_data/misc/CurrentNames.csv")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		team             string
+		date             time.Time
+		expectedLeague   string
+		expectedDivision string
+		expectedFound    bool
+	}{
+		{
+			name:             "current team",
+			team:             "SFN",
+			date:             time.Date(2022, time.July, 1, 0, 0, 0, 0, time.UTC),
+			expectedLeague:   "NL",
+			expectedDivision: "W",
+			expectedFound:    true,
+		},
+		{
+			name:             "league switch",
+			team:             "HOU",
+			date:             time.Date(2013, time.July, 1, 0, 0, 0, 0, time.UTC),
+			expectedLeague:   "AL",
+			expectedDivision: "W",
+			expectedFound:    true,
+		},
+		{
+			name:             "date before listed start falls back to year",
+			team:             "SLN",
+			date:             time.Date(1892, time.April, 12, 0, 0, 0, 0, time.UTC),
+			expectedLeague:   "NL",
+			expectedDivision: "",
+			expectedFound:    true,
+		},
+		{
+			name: "defunct team",
+			team: "BLN",
+			date: time.Date(1895, time.July, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info, ok := directory.Lookup(test.team, test.date)
+			assert.Equal(t, test.expectedFound, ok)
+			assert.Equal(t, test.expectedLeague, info.League)
+			assert.Equal(t, test.expectedDivision, info.Division)
+		})
+	}
+}
+
+func TestStandingsOn(t *testing.T) {
+	directory := TeamDirectory{
+		"AAA": {{Franchise: "AAA", Team: "AAA", League: "NL", Division: "E", Start: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)}},
+		"BBB": {{Franchise: "BBB", Team: "BBB", League: "NL", Division: "E", Start: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+	seasons := []*Season{
+		testSeason("AAA", 2020, "NL", Win, Win, Loss, Win),
+		testSeason("BBB", 2020, "NL", Loss, Win, Win, Tie),
+		testSeason("CCC", 2020, "UA", Loss, Loss),
+	}
+	engine := newStandingsEngine(seasons, directory)
+
+	standings := engine.On(time.Date(2020, time.April, 3, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, []StandingsTable{
+		{
+			League:   "NL",
+			Division: "E",
+			Entries: []StandingsEntry{
+				{Team: "AAA", Franchise: "AAA", Wins: 2, Losses: 1, GamesBehind: 0, GamesRemaining: 1},
+				{Team: "BBB", Franchise: "BBB", Wins: 2, Losses: 1, GamesBehind: 0, GamesRemaining: 1},
+			},
+		},
+		{
+			League: "UA",
+			Entries: []StandingsEntry{
+				{Team: "CCC", Franchise: "CCC", Wins: 0, Losses: 2, GamesBehind: 0},
+			},
+		},
+	}, standings.Tables)
+
+	standings = engine.On(time.Date(2020, time.April, 4, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, []StandingsEntry{
+		{Team: "AAA", Franchise: "AAA", Wins: 3, Losses: 1, GamesBehind: 0},
+		{Team: "BBB", Franchise: "BBB", Wins: 2, Losses: 1, Ties: 1, GamesBehind: 0.5},
+	}, standings.Tables[0].Entries)
+
+	assert.Len(t, engine.Timeline(), 4)
+}
@@ -0,0 +1,280 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// Streakiness tests whether a season's wins and losses bunched together
+// more than independent games would. Ties are left out throughout.
+//
+// The Wald-Wolfowitz runs test compares the number of runs of wins or
+// losses with the number expected if the same wins and losses were shuffled;
+// a negative RunsZ means fewer, longer runs than chance, a streaky season.
+// Autocorrelation is the lag-1 correlation of results, positive when a win
+// tends to follow a win. The expected longest streaks assume every game is
+// won with the season's winning percentage.
+type Streakiness struct {
+	Franchise           string
+	Team                string
+	Year                int
+	Wins                int
+	Losses              int
+	Runs                int
+	ExpectedRuns        float64
+	RunsZ               float64
+	RunsPValue          float64
+	Autocorrelation     float64
+	LongestWin          int
+	LongestLoss         int
+	ExpectedLongestWin  float64
+	ExpectedLongestLoss float64
+}
+
+// Streakiness computes the season's streakiness statistics.
+func (s Season) Streakiness() Streakiness {
+	st := Streakiness{Franchise: s.Franchise, Team: s.Team, Year: s.Year}
+	var results []Result
+	for _, game := range s.Games {
+		if game.Result == Win || game.Result == Loss {
+			results = append(results, game.Result)
+		}
+	}
+	current := 0
+	for i, result := range results {
+		if result == Win {
+			st.Wins++
+		} else {
+			st.Losses++
+		}
+		if i == 0 || result != results[i-1] {
+			st.Runs++
+			current = 0
+		}
+		current++
+		if result == Win && current > st.LongestWin {
+			st.LongestWin = current
+		}
+		if result == Loss && current > st.LongestLoss {
+			st.LongestLoss = current
+		}
+	}
+
+	n := float64(len(results))
+	if st.Wins == 0 || st.Losses == 0 {
+		st.ExpectedRuns = float64(st.Runs)
+		st.RunsPValue = 1
+	} else {
+		wins, losses := float64(st.Wins), float64(st.Losses)
+		st.ExpectedRuns = 2*wins*losses/n + 1
+		variance := (st.ExpectedRuns - 1) * (st.ExpectedRuns - 2) / (n - 1)
+		if variance > 0 {
+			st.RunsZ = (float64(st.Runs) - st.ExpectedRuns) / math.Sqrt(variance)
+		}
+		st.RunsPValue = math.Erfc(math.Abs(st.RunsZ) / math.Sqrt2)
+		st.Autocorrelation = lagOneAutocorrelation(results)
+	}
+
+	p := ratio(st.Wins, len(results))
+	st.ExpectedLongestWin = expectedLongestRun(len(results), p)
+	st.ExpectedLongestLoss = expectedLongestRun(len(results), 1-p)
+	return st
+}
+
+// lagOneAutocorrelation correlates each result, scored 1 for a win and -1
+// for a loss, with the next.
+func lagOneAutocorrelation(results []Result) float64 {
+	x := make([]float64, len(results))
+	var mean float64
+	for i, result := range results {
+		x[i] = -1
+		if result == Win {
+			x[i] = 1
+		}
+		mean += x[i]
+	}
+	mean /= float64(len(x))
+	var num, den float64
+	for i := range x {
+		den += (x[i] - mean) * (x[i] - mean)
+		if i > 0 {
+			num += (x[i-1] - mean) * (x[i] - mean)
+		}
+	}
+	if den == 0 {
+		return 0
+	}
+	return num / den
+}
+
+// expectedLongestRun is the expected length of the longest run of successes
+// in n independent trials that each succeed with probability p. It sums the
+// chance the longest run reaches each length k, found by conditioning on
+// where the last failure falls, and stops once those chances are negligible.
+func expectedLongestRun(n int, p float64) float64 {
+	if n == 0 || p == 0 {
+		return 0
+	}
+	if p == 1 {
+		return float64(n)
+	}
+	q := 1 - p
+	var expected float64
+	noRun := make([]float64, n+1)
+	for k := 1; k <= n; k++ {
+		// noRun[i] is the chance i trials have no run of k successes.
+		noRun[0] = 1
+		for i := 1; i <= n; i++ {
+			var total float64
+			pj := 1.0
+			for j := 0; j < k && j < i; j++ {
+				total += pj * q * noRun[i-j-1]
+				pj *= p
+			}
+			if i < k {
+				total += pj
+			}
+			noRun[i] = total
+		}
+		reached := 1 - noRun[n]
+		expected += reached
+		if reached < 1e-12 {
+			break
+		}
+	}
+	return expected
+}
+
+// streakinessRankings returns the streakiness of every season with at least
+// minGames decided games, streakiest first.
+func streakinessRankings(seasons []*Season, minGames int) []Streakiness {
+	var rankings []Streakiness
+	for _, season := range seasons {
+		st := season.Streakiness()
+		if st.Wins+st.Losses < minGames {
+			continue
+		}
+		rankings = append(rankings, st)
+	}
+	sort.Slice(rankings, func(i, j int) bool {
+		if rankings[i].RunsZ != rankings[j].RunsZ {
+			return rankings[i].RunsZ < rankings[j].RunsZ
+		}
+		if rankings[i].Year != rankings[j].Year {
+			return rankings[i].Year < rankings[j].Year
+		}
+		return rankings[i].Franchise < rankings[j].Franchise
+	})
+	return rankings
+}
+
+func printStreakiness(rows []Streakiness) {
+	fmt.Println("Season\tRecord\tRuns\tExpected\tZ\tP\tAutocorr\tLongestW\tExpected\tLongestL\tExpected")
+	for _, st := range rows {
+		fmt.Printf("%d %s\t%d-%d\t%d\t%.1f\t%.2f\t%.4f\t%.3f\t%d\t%.1f\t%d\t%.1f\n",
+			st.Year, st.Team, st.Wins, st.Losses, st.Runs, st.ExpectedRuns, st.RunsZ, st.RunsPValue,
+			st.Autocorrelation, st.LongestWin, st.ExpectedLongestWin, st.LongestLoss, st.ExpectedLongestLoss)
+	}
+}
+
+// streakinessCmd represents the streakiness command
+var streakinessCmd = &cobra.Command{
+	Use:   "streakiness",
+	Short: "Rank the streakiest and least streaky seasons",
+	Long: `Tests every season for streakiness with the Wald-Wolfowitz runs test: a season with far
+fewer runs of wins or losses than its record would produce by chance is streaky, and one with
+far more alternated unusually often. Also reports the lag-1 autocorrelation of results and the
+longest winning and losing streaks against those expected if every game were independent.
+Ties are left out.
+
+Inputs:
+
+franchise, year: Report a single season instead of ranking them all.
+min-games: The fewest decided games for a season to be ranked.
+top: How many seasons to list at each end of the ranking.
+format: "text" or "json".
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		franchise, err := cmd.Flags().GetString("franchise")
+		if err != nil {
+			return err
+		}
+		year, err := cmd.Flags().GetInt("year")
+		if err != nil {
+			return err
+		}
+		minGames, err := cmd.Flags().GetInt("min-games")
+		if err != nil {
+			return err
+		}
+		top, err := cmd.Flags().GetInt("top")
+		if err != nil {
+			return err
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown format %q", format)
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+
+		if franchise != "" {
+			season, ok := teamsBySeason.m[franchise][year]
+			if !ok {
+				return fmt.Errorf("no games found for %s in %d", franchise, year)
+			}
+			st := season.Streakiness()
+			if format == "json" {
+				return writeJSON(st)
+			}
+			printStreakiness([]Streakiness{st})
+			return nil
+		}
+
+		var seasons []*Season
+		for _, franchiseSeasons := range teamsBySeason.BySortedSeason() {
+			seasons = append(seasons, franchiseSeasons...)
+		}
+		rankings := streakinessRankings(seasons, minGames)
+		streakiest := rankings
+		if len(streakiest) > top {
+			streakiest = streakiest[:top]
+		}
+		var leastStreaky []Streakiness
+		for i := len(rankings) - 1; i >= 0 && len(leastStreaky) < top; i-- {
+			leastStreaky = append(leastStreaky, rankings[i])
+		}
+		if format == "json" {
+			return writeJSON(struct {
+				Streakiest   []Streakiness
+				LeastStreaky []Streakiness
+			}{streakiest, leastStreaky})
+		}
+		fmt.Println("Streakiest")
+		printStreakiness(streakiest)
+		fmt.Println()
+		fmt.Println("Least streaky")
+		printStreakiness(leastStreaky)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(streakinessCmd)
+	streakinessCmd.Flags().String("franchise", "", "franchise of a single season to report")
+	streakinessCmd.Flags().Int("year", 0, "year of a single season to report")
+	streakinessCmd.Flags().Int("min-games", 100, "fewest decided games for a season to be ranked")
+	streakinessCmd.Flags().Int("top", 10, "number of seasons to list at each end")
+	streakinessCmd.Flags().String("format", "text", "output format: text or json")
+}
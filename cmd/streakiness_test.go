@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeasonStreakiness(t *testing.T) {
+	season := testSeason("SFN", 2021, "NL", Win, Win, Tie, Win, Loss, Loss, Loss)
+	st := season.Streakiness()
+	assert.Equal(t, 3, st.Wins)
+	assert.Equal(t, 3, st.Losses)
+	assert.Equal(t, 2, st.Runs)
+	assert.InDelta(t, 4, st.ExpectedRuns, 1e-9)
+	assert.InDelta(t, -2/math.Sqrt(1.2), st.RunsZ, 1e-9)
+	assert.InDelta(t, .0679, st.RunsPValue, 1e-4)
+	assert.InDelta(t, .5, st.Autocorrelation, 1e-9)
+	assert.Equal(t, 3, st.LongestWin)
+	assert.Equal(t, 3, st.LongestLoss)
+	assert.InDelta(t, expectedLongestRun(6, .5), st.ExpectedLongestWin, 1e-9)
+
+	alternating := testSeason("SFN", 2022, "NL", Win, Loss, Win, Loss, Win, Loss).Streakiness()
+	assert.Equal(t, 6, alternating.Runs)
+	assert.Greater(t, alternating.RunsZ, 0.0)
+	assert.InDelta(t, -5.0/6, alternating.Autocorrelation, 1e-9)
+
+	perfect := testSeason("SFN", 2023, "NL", Win, Win, Win).Streakiness()
+	assert.Zero(t, perfect.RunsZ)
+	assert.Equal(t, 1.0, perfect.RunsPValue)
+	assert.Equal(t, 3.0, perfect.ExpectedLongestWin)
+	assert.Zero(t, perfect.ExpectedLongestLoss)
+}
+
+func TestExpectedLongestRun(t *testing.T) {
+	tests := []struct {
+		n        int
+		p        float64
+		expected float64
+	}{
+		{n: 0, p: .5, expected: 0},
+		{n: 1, p: .5, expected: .5},
+		{n: 2, p: .5, expected: 1},
+		{n: 3, p: .5, expected: 11.0 / 8},
+		{n: 4, p: 1, expected: 4},
+		{n: 4, p: 0, expected: 0},
+	}
+	for _, tt := range tests {
+		assert.InDelta(t, tt.expected, expectedLongestRun(tt.n, tt.p), 1e-9, "n=%d p=%v", tt.n, tt.p)
+	}
+}
+
+func TestStreakinessRankings(t *testing.T) {
+	streaky := testSeason("PHI", 1892, "NL", Win, Win, Win, Loss, Loss, Loss)
+	choppy := testSeason("SLN", 2005, "NL", Win, Loss, Win, Loss, Win, Loss)
+	short := testSeason("BRO", 1900, "NL", Win, Win, Loss)
+	rankings := streakinessRankings([]*Season{choppy, short, streaky}, 6)
+	require.Len(t, rankings, 2)
+	assert.Equal(t, "PHI", rankings[0].Franchise)
+	assert.Equal(t, "SLN", rankings[1].Franchise)
+}
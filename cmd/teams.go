@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"os"
+	"time"
+)
+
+// TeamInfo is a single row of Retrosheet's CurrentNames.csv: the league and
+// division a team code played in over a span of dates.
+type TeamInfo struct {
+	Franchise string
+	Team      string
+	League    string
+	Division  string
+	City      string
+	Nickname  string
+	Start     time.Time
+	End       time.Time // zero if the team is still active
+}
+
+func (ti TeamInfo) contains(date time.Time) bool {
+	if date.Before(ti.Start) {
+		return false
+	}
+	return ti.End.IsZero() || !date.After(ti.End)
+}
+
+func (ti TeamInfo) containsYear(year int) bool {
+	if year < ti.Start.Year() {
+		return false
+	}
+	return ti.End.IsZero() || year <= ti.End.Year()
+}
+
+// TeamDirectory maps a team code to every CurrentNames.csv row for it.
+type TeamDirectory map[string][]TeamInfo
+
+// Lookup finds the row describing team on date. Retrosheet's start and end
+// dates are the first and last games of a span, so when no row covers the
+// exact date (spring dates, off days before the opener) the row covering the
+// year is used instead.
+func (td TeamDirectory) Lookup(team string, date time.Time) (TeamInfo, bool) {
+	infos := td[team]
+	for _, info := range infos {
+		if info.contains(date) {
+			return info, true
+		}
+	}
+	for i := len(infos) - 1; i >= 0; i-- {
+		if infos[i].containsYear(date.Year()) {
+			return infos[i], true
+		}
+	}
+	return TeamInfo{}, false
+}
+
+func getTeamDirectory(path string) (TeamDirectory, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	csvReader := csv.NewReader(f)
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	td := make(TeamDirectory)
+	for _, record := range records {
+		start, err := time.Parse("1/2/2006", record[7])
+		if err != nil {
+			return nil, err
+		}
+		var end time.Time
+		if record[8] != "" {
+			end, err = time.Parse("1/2/2006", record[8])
+			if err != nil {
+				return nil, err
+			}
+		}
+		td[record[1]] = append(td[record[1]], TeamInfo{
+			Franchise: record[0],
+			Team:      record[1],
+			League:    record[2],
+			Division:  record[3],
+			City:      record[4],
+			Nickname:  record[5],
+			Start:     start,
+			End:       end,
+		})
+	}
+	return td, nil
+}
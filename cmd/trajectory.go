@@ -0,0 +1,189 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// TrajectoryPoint is where a season stood after one of its games.
+type TrajectoryPoint struct {
+	Game   int
+	Date   time.Time
+	Record SeasonRecord
+	// GamesOver500 is wins less losses; negative when under .500.
+	GamesOver500 int
+}
+
+// Trajectory is a season's games over .500 after every game.
+type Trajectory struct {
+	Franchise string
+	Team      string
+	Year      int
+	Points    []TrajectoryPoint
+}
+
+func (t Trajectory) Label() string {
+	return fmt.Sprintf("%d %s", t.Year, t.Team)
+}
+
+func (s Season) Trajectory() Trajectory {
+	t := Trajectory{Franchise: s.Franchise, Team: s.Team, Year: s.Year}
+	var record SeasonRecord
+	for i, game := range s.Games {
+		addResult(&record, game.Result)
+		t.Points = append(t.Points, TrajectoryPoint{
+			Game:         i + 1,
+			Date:         game.Date,
+			Record:       record,
+			GamesOver500: record.Wins - record.Losses,
+		})
+	}
+	return t
+}
+
+// parseSeasonRef reads a season given as FRANCHISE:YEAR, e.g. SFN:2021.
+func parseSeasonRef(ref string) (string, int, error) {
+	franchise, yearText, ok := strings.Cut(ref, ":")
+	if !ok || franchise == "" {
+		return "", 0, fmt.Errorf("season %q: expected FRANCHISE:YEAR", ref)
+	}
+	year, err := strconv.Atoi(yearText)
+	if err != nil {
+		return "", 0, fmt.Errorf("season %q: %w", ref, err)
+	}
+	return franchise, year, nil
+}
+
+// writeTrajectoryCSV writes one row per game of every trajectory.
+func writeTrajectoryCSV(csvWriter *csv.Writer, trajectories []Trajectory) error {
+	header := []string{"Franchise", "Team", "Year", "Game", "Date", "Wins", "Losses", "Ties", "GamesOver500"}
+	if err := csvWriter.Write(header); err != nil {
+		return err
+	}
+	for _, t := range trajectories {
+		for _, point := range t.Points {
+			row := []string{
+				t.Franchise,
+				t.Team,
+				strconv.Itoa(t.Year),
+				strconv.Itoa(point.Game),
+				point.Date.Format("2006-01-02"),
+				strconv.Itoa(point.Record.Wins),
+				strconv.Itoa(point.Record.Losses),
+				strconv.Itoa(point.Record.Ties),
+				strconv.Itoa(point.GamesOver500),
+			}
+			if err := csvWriter.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// writeTrajectorySVG draws the trajectories as a line chart of games over
+// .500 against games played, each line starting from 0 before the first
+// game.
+func writeTrajectorySVG(w io.Writer, trajectories []Trajectory) error {
+	return trajectoryChart(trajectories).WriteSVG(w)
+}
+
+func trajectoryChart(trajectories []Trajectory) LineChart {
+	chart := LineChart{XLabel: "Games played", YLabel: "Games over .500", SignedY: true, Whole: true}
+	for _, t := range trajectories {
+		series := ChartSeries{Label: t.Label(), Points: []ChartPoint{{}}}
+		for _, point := range t.Points {
+			series.Points = append(series.Points, ChartPoint{X: float64(point.Game), Y: float64(point.GamesOver500)})
+		}
+		chart.Series = append(chart.Series, series)
+	}
+	return chart
+}
+
+// trajectoryCmd represents the trajectory command
+var trajectoryCmd = &cobra.Command{
+	Use:   "trajectory",
+	Short: "Export seasons' games over .500 after every game, or chart them",
+	Long: `Follows each selected season game by game, giving its record and games over .500 (wins
+less losses) after every game, with dates.
+
+Inputs:
+
+season: A season to include as FRANCHISE:YEAR, e.g. SFN:2021. Repeat it to compare seasons.
+format: "csv", "json" or "svg". svg draws every season as a line on one chart.
+out-file: Where to write the output; standard output when left out.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		refs, err := cmd.Flags().GetStringArray("season")
+		if err != nil {
+			return err
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != "csv" && format != "json" && format != "svg" {
+			return fmt.Errorf("unknown format %q", format)
+		}
+		outFilePath, err := cmd.Flags().GetString("out-file")
+		if err != nil {
+			return err
+		}
+		teamsBySeason, err := GetTeamsBySeason(rsDataDir)
+		if err != nil {
+			return err
+		}
+		var trajectories []Trajectory
+		for _, ref := range refs {
+			franchise, year, err := parseSeasonRef(ref)
+			if err != nil {
+				return err
+			}
+			season, ok := teamsBySeason.m[franchise][year]
+			if !ok {
+				return fmt.Errorf("no games found for %s in %d", franchise, year)
+			}
+			trajectories = append(trajectories, season.Trajectory())
+		}
+
+		out := os.Stdout
+		if outFilePath != "" {
+			f, err := os.Create(outFilePath)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+		switch format {
+		case "json":
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(trajectories)
+		case "svg":
+			return writeTrajectorySVG(out, trajectories)
+		default:
+			return writeTrajectoryCSV(csv.NewWriter(out), trajectories)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(trajectoryCmd)
+	trajectoryCmd.Flags().StringArray("season", nil, "season to include as FRANCHISE:YEAR; repeat for more")
+	trajectoryCmd.Flags().String("format", "csv", "output format: csv, json or svg")
+	trajectoryCmd.Flags().String("out-file", "", "path to write the output to")
+	trajectoryCmd.MarkFlagRequired("season")
+}
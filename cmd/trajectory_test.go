@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeasonTrajectory(t *testing.T) {
+	season := testSeason("SFN", 2021, "NL", Loss, Win, Win, Tie, Win)
+	trajectory := season.Trajectory()
+	assert.Equal(t, "2021 SFN", trajectory.Label())
+	require.Len(t, trajectory.Points, 5)
+	over := make([]int, len(trajectory.Points))
+	for i, point := range trajectory.Points {
+		over[i] = point.GamesOver500
+	}
+	assert.Equal(t, []int{-1, 0, 1, 1, 2}, over)
+	assert.Equal(t, TrajectoryPoint{
+		Game:         4,
+		Date:         time.Date(2021, time.April, 4, 0, 0, 0, 0, time.UTC),
+		Record:       SeasonRecord{Wins: 2, Ties: 1, Losses: 1},
+		GamesOver500: 1,
+	}, trajectory.Points[3])
+}
+
+func TestParseSeasonRef(t *testing.T) {
+	franchise, year, err := parseSeasonRef("SFN:2021")
+	require.NoError(t, err)
+	assert.Equal(t, "SFN", franchise)
+	assert.Equal(t, 2021, year)
+
+	for _, ref := range []string{"SFN", "SFN:", ":2021", "SFN:twenty"} {
+		_, _, err := parseSeasonRef(ref)
+		assert.Error(t, err, ref)
+	}
+}
+
+func TestWriteTrajectoryCSV(t *testing.T) {
+	trajectories := []Trajectory{testSeason("SFN", 2021, "NL", Win, Loss).Trajectory()}
+	var buf bytes.Buffer
+	require.NoError(t, writeTrajectoryCSV(csv.NewWriter(&buf), trajectories))
+	assert.Equal(t, `Franchise,Team,Year,Game,Date,Wins,Losses,Ties,GamesOver500
+SFN,SFN,2021,1,2021-04-01,1,0,0,1
+SFN,SFN,2021,2,2021-04-02,1,1,0,0
+`, buf.String())
+}
+
+func TestWriteTrajectorySVG(t *testing.T) {
+	trajectories := []Trajectory{
+		testSeason("SFN", 2021, "NL", Win, Win, Win).Trajectory(),
+		testSeason("LAN", 2021, "NL", Loss, Loss).Trajectory(),
+	}
+	var buf bytes.Buffer
+	require.NoError(t, writeTrajectorySVG(&buf, trajectories))
+	svg := buf.String()
+	assert.True(t, strings.HasPrefix(svg, "<svg"))
+	assert.True(t, strings.HasSuffix(svg, "</svg>\n"))
+	assert.Equal(t, 2, strings.Count(svg, "<polyline"))
+	assert.Contains(t, svg, ">2021 SFN<")
+	assert.Contains(t, svg, ">2021 LAN<")
+	assert.Contains(t, svg, ">+3<")
+	assert.Contains(t, svg, ">-2<")
+
+	// A chart with no seasons is still a complete document.
+	var empty bytes.Buffer
+	require.NoError(t, writeTrajectorySVG(&empty, nil))
+	assert.Contains(t, empty.String(), "</svg>")
+}
@@ -0,0 +1,218 @@
+/*
+Copyright © 2023 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// UmpireStats describes the games an umpire worked behind the plate. Each
+// Expected* value is what the league averaged in the same seasons over the
+// same games, so the indexes compare umpires from different eras: 100 is
+// average for the era.
+type UmpireStats struct {
+	Umpire    Person
+	FirstYear int
+	LastYear  int
+	Games     int
+
+	Runs         int
+	ExpectedRuns float64
+
+	HomeWins         int
+	Decided          int
+	ExpectedHomeWins float64
+
+	// PlateAppearances, Strikeouts and Walks cover the games with box
+	// scores.
+	PlateAppearances   int
+	Strikeouts         int
+	Walks              int
+	ExpectedStrikeouts float64
+	ExpectedWalks      float64
+
+	// TimedGames and Minutes cover the games of known duration.
+	TimedGames      int
+	Minutes         int
+	ExpectedMinutes float64
+}
+
+func eraIndex(actual int, expected float64) float64 {
+	if expected == 0 {
+		return 0
+	}
+	return float64(actual) / expected * 100
+}
+
+func (us UmpireStats) RunsPerGame() float64    { return ratio(us.Runs, us.Games) }
+func (us UmpireStats) HomeWinPct() float64     { return ratio(us.HomeWins, us.Decided) }
+func (us UmpireStats) StrikeoutRate() float64  { return ratio(us.Strikeouts, us.PlateAppearances) }
+func (us UmpireStats) WalkRate() float64       { return ratio(us.Walks, us.PlateAppearances) }
+func (us UmpireStats) AverageMinutes() float64 { return ratio(us.Minutes, us.TimedGames) }
+
+func (us UmpireStats) RunsIndex() float64      { return eraIndex(us.Runs, us.ExpectedRuns) }
+func (us UmpireStats) HomeWinIndex() float64   { return eraIndex(us.HomeWins, us.ExpectedHomeWins) }
+func (us UmpireStats) StrikeoutIndex() float64 { return eraIndex(us.Strikeouts, us.ExpectedStrikeouts) }
+func (us UmpireStats) WalkIndex() float64      { return eraIndex(us.Walks, us.ExpectedWalks) }
+func (us UmpireStats) DurationIndex() float64  { return eraIndex(us.Minutes, us.ExpectedMinutes) }
+
+// umpireTotals accumulates the counts shared by an umpire and a league
+// season.
+type umpireTotals struct {
+	games, runs                         int
+	homeWins, decided                   int
+	plateAppearances, strikeouts, walks int
+	timedGames, minutes                 int
+}
+
+func (ut *umpireTotals) add(game *RetrosheetGame) {
+	ut.games++
+	ut.runs += game.VisitingScore + game.HomeScore
+	switch game.HomeResult(resultMode) {
+	case Win:
+		ut.homeWins++
+		ut.decided++
+	case Loss:
+		ut.decided++
+	}
+	if pa := game.VisitingStats.PlateAppearances() + game.HomeStats.PlateAppearances(); pa > 0 {
+		ut.plateAppearances += pa
+		ut.strikeouts += game.VisitingStats.Strikeouts + game.HomeStats.Strikeouts
+		ut.walks += game.VisitingStats.Walks + game.HomeStats.Walks
+	}
+	if game.DurationMinutes > 0 {
+		ut.timedGames++
+		ut.minutes += game.DurationMinutes
+	}
+}
+
+// umpireStats reports every home-plate umpire with at least minGames games
+// between since and until, inclusive, with the highest run index first.
+func umpireStats(games []*RetrosheetGame, since, until, minGames int) []UmpireStats {
+	league := make(map[int]*umpireTotals)
+	worked := make(map[string][]*RetrosheetGame)
+	for _, game := range games {
+		year := game.Date.Year()
+		if year < since || year > until {
+			continue
+		}
+		if league[year] == nil {
+			league[year] = &umpireTotals{}
+		}
+		league[year].add(game)
+		if id := game.Umpires.HomePlate.ID; id != "" {
+			worked[id] = append(worked[id], game)
+		}
+	}
+
+	var stats []UmpireStats
+	for _, games := range worked {
+		if len(games) < minGames {
+			continue
+		}
+		var totals umpireTotals
+		us := UmpireStats{Umpire: games[0].Umpires.HomePlate, FirstYear: games[0].Date.Year()}
+		for _, game := range games {
+			before := totals
+			totals.add(game)
+			year := league[game.Date.Year()]
+			us.LastYear = game.Date.Year()
+			us.ExpectedRuns += ratio(year.runs, year.games)
+			us.ExpectedHomeWins += float64(totals.decided-before.decided) * ratio(year.homeWins, year.decided)
+			pa := totals.plateAppearances - before.plateAppearances
+			us.ExpectedStrikeouts += float64(pa) * ratio(year.strikeouts, year.plateAppearances)
+			us.ExpectedWalks += float64(pa) * ratio(year.walks, year.plateAppearances)
+			if totals.timedGames > before.timedGames {
+				us.ExpectedMinutes += ratio(year.minutes, year.timedGames)
+			}
+		}
+		us.Games = totals.games
+		us.Runs = totals.runs
+		us.HomeWins = totals.homeWins
+		us.Decided = totals.decided
+		us.PlateAppearances = totals.plateAppearances
+		us.Strikeouts = totals.strikeouts
+		us.Walks = totals.walks
+		us.TimedGames = totals.timedGames
+		us.Minutes = totals.minutes
+		stats = append(stats, us)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].RunsIndex() != stats[j].RunsIndex() {
+			return stats[i].RunsIndex() > stats[j].RunsIndex()
+		}
+		return stats[i].Umpire.ID < stats[j].Umpire.ID
+	})
+	return stats
+}
+
+// umpiresCmd represents the umpires command
+var umpiresCmd = &cobra.Command{
+	Use:   "umpires",
+	Short: "Scoring, home win rate, strikeouts, walks and game time by home-plate umpire",
+	Long: `Reports the games each home-plate umpire worked: runs per game, the home team's winning
+percentage, strikeout and walk rates per plate appearance and average game time. Each is also
+given as an index against the league average in the same seasons, where 100 is average for the
+era, so umpires from different eras can be compared. Umpires are listed by run index, highest
+first. Strikeout and walk rates need box scores, and game time needs a recorded duration; games
+without them are left out of those columns.
+
+Inputs:
+
+since, until: The seasons to include.
+min-games: The fewest games behind the plate for an umpire to be listed.
+format: "text" or "json".
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown format %q", format)
+		}
+		since, err := cmd.Flags().GetInt("since")
+		if err != nil {
+			return err
+		}
+		until, err := cmd.Flags().GetInt("until")
+		if err != nil {
+			return err
+		}
+		minGames, err := cmd.Flags().GetInt("min-games")
+		if err != nil {
+			return err
+		}
+		games, err := sortedRetrosheetGames(rsDataDir)
+		if err != nil {
+			return err
+		}
+		stats := umpireStats(games, since, until, minGames)
+		if format == "json" {
+			return writeJSON(stats)
+		}
+		fmt.Println("Umpire\tYears\tGames\tRuns/Game\tRunsIdx\tHomeWinPct\tHomeWinIdx\tK%\tKIdx\tBB%\tBBIdx\tMinutes\tMinutesIdx")
+		for _, us := range stats {
+			fmt.Printf("%s\t%d-%d\t%d\t%.2f\t%.0f\t%s\t%.0f\t%.1f\t%.0f\t%.1f\t%.0f\t%.0f\t%.0f\n",
+				us.Umpire.Name, us.FirstYear, us.LastYear, us.Games,
+				us.RunsPerGame(), us.RunsIndex(),
+				formatPct(us.HomeWinPct()), us.HomeWinIndex(),
+				us.StrikeoutRate()*100, us.StrikeoutIndex(),
+				us.WalkRate()*100, us.WalkIndex(),
+				us.AverageMinutes(), us.DurationIndex())
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(umpiresCmd)
+	umpiresCmd.Flags().Int("since", 0, "first season to include")
+	umpiresCmd.Flags().Int("until", 9999, "last season to include")
+	umpiresCmd.Flags().Int("min-games", 500, "fewest games behind the plate for an umpire to be listed")
+	umpiresCmd.Flags().String("format", "text", "output format: text or json")
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUmpiresAndTeamStats(t *testing.T) {
+	games, err := sortedRetrosheetGames("./test_data")
+	require.NoError(t, err)
+	game := games[0]
+	require.Equal(t, "LAN", game.HomeTeam)
+	assert.Equal(t, Umpires{
+		HomePlate:  Person{ID: "millb901", Name: "Bill Miller"},
+		FirstBase:  Person{ID: "mosce901", Name: "Edwin Moscoso"},
+		SecondBase: Person{ID: "eddid901", Name: "Doug Eddings"},
+		ThirdBase:  Person{ID: "drakr901", Name: "Rob Drake"},
+	}, game.Umpires)
+	assert.Equal(t, TeamStats{
+		AtBats: 32, Hits: 8, RBI: 1, SacFlies: 1, Strikeouts: 8, GroundedIntoDoublePlays: 1, LeftOnBase: 5,
+		PitchersUsed: 6, IndividualEarnedRuns: 8, TeamEarnedRuns: 8,
+		Putouts: 24, Assists: 12, Errors: 1, DoublePlays: 1,
+	}, game.VisitingStats)
+	assert.Equal(t, TeamStats{
+		AtBats: 37, Hits: 12, Doubles: 4, HomeRuns: 1, RBI: 8, HitByPitch: 1, Walks: 5, Strikeouts: 6, GroundedIntoDoublePlays: 1, LeftOnBase: 11,
+		PitchersUsed: 5, IndividualEarnedRuns: 1, TeamEarnedRuns: 1,
+		Putouts: 27, Assists: 11, Errors: 1, DoublePlays: 2,
+	}, game.HomeStats)
+	assert.Equal(t, 43, game.HomeStats.PlateAppearances())
+}
+
+func TestUmpireStats(t *testing.T) {
+	games, err := sortedRetrosheetGames("./test_data")
+	require.NoError(t, err)
+
+	stats := umpireStats(games, 0, 9999, 2)
+	require.Len(t, stats, 4)
+	// Hernandez and Vanover both saw 30 runs against 21 expected; ties go by ID.
+	top := stats[0]
+	assert.Equal(t, "herna901", top.Umpire.ID)
+	assert.Equal(t, "vanol901", stats[1].Umpire.ID)
+	assert.Equal(t, 2000, top.FirstYear)
+	assert.Equal(t, 2001, top.LastYear)
+	assert.Equal(t, 2, top.Games)
+	assert.Equal(t, 30, top.Runs)
+	assert.InDelta(t, 21, top.ExpectedRuns, 1e-9)
+	assert.InDelta(t, 142.857, top.RunsIndex(), 1e-3)
+	assert.Equal(t, 0, top.HomeWins)
+	assert.Equal(t, 2, top.Decided)
+	// The home team won three of four in 2000 and two of four in 2001.
+	assert.InDelta(t, 1.25, top.ExpectedHomeWins, 1e-9)
+	assert.Equal(t, 2, top.TimedGames)
+	assert.Equal(t, 206, top.Minutes)
+
+	assert.Empty(t, umpireStats(games, 0, 9999, 3))
+	assert.Len(t, umpireStats(games, 2001, 2001, 1), 4)
+}
@@ -2,13 +2,18 @@ module github.com/isaachess/mlb-season-comparer
 
 go 1.19
 
+require (
+	github.com/spf13/cobra v1.7.0
+	github.com/stretchr/testify v1.8.2
+	golang.org/x/sync v0.1.0
+	golang.org/x/term v0.15.0
+)
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/spf13/cobra v1.7.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
-	github.com/stretchr/testify v1.8.2 // indirect
-	golang.org/x/sync v0.1.0 // indirect
+	golang.org/x/sys v0.15.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )